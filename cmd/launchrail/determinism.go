@@ -0,0 +1,116 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/bxrne/launchrail/internal/config"
+	storagepkg "github.com/bxrne/launchrail/internal/storage"
+	"github.com/bxrne/launchrail/pkg/openrocket"
+	"github.com/bxrne/launchrail/pkg/simulation"
+	"github.com/bxrne/launchrail/pkg/thrustcurves"
+	"github.com/zerodha/logf"
+)
+
+// runResult is what must match bit-for-bit between deterministic runs
+type runResult struct {
+	recordHash string
+	metrics    map[string]float64
+}
+
+// verifyDeterminism runs the same config twice and returns the values that diverged
+func verifyDeterminism(cfg *config.Config, log *logf.Logger, motorData *thrustcurves.MotorData, orkData *openrocket.OpenrocketDocument) ([]string, error) {
+	var results [2]runResult
+	for i := range results {
+		result, err := runOnce(cfg, log, motorData, orkData)
+		if err != nil {
+			return nil, fmt.Errorf("run %d: %w", i+1, err)
+		}
+		results[i] = result
+	}
+
+	var diverged []string
+	if results[0].recordHash != results[1].recordHash {
+		diverged = append(diverged, fmt.Sprintf("record hash: %s != %s", results[0].recordHash, results[1].recordHash))
+	}
+	for _, name := range []string{"apogee", "time_to_apogee", "max_velocity", "max_accel", "max_mach", "ground_hit_velocity", "total_flight_time"} {
+		first, second := results[0].metrics[name], results[1].metrics[name]
+		if math.Float64bits(first) != math.Float64bits(second) {
+			diverged = append(diverged, fmt.Sprintf("%s: %v != %v", name, first, second))
+		}
+	}
+	return diverged, nil
+}
+
+// runOnce runs a full simulation into a record in its own temporary directory and hashes it.
+// The directory is removed afterwards so verification leaves the motion store untouched.
+func runOnce(cfg *config.Config, log *logf.Logger, motorData *thrustcurves.MotorData, orkData *openrocket.OpenrocketDocument) (runResult, error) {
+	dir, err := os.MkdirTemp("", "launchrail-determinism-*")
+	if err != nil {
+		return runResult{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	storage, err := storagepkg.NewStorageIn(dir)
+	if err != nil {
+		return runResult{}, err
+	}
+	defer storage.Close() // Releases the file on early returns, a no-op after the checked Close below
+
+	if err := storage.Init(motionHeaders(cfg)); err != nil {
+		return runResult{}, err
+	}
+
+	sim, err := simulation.NewSimulation(cfg, log, storage)
+	if err != nil {
+		return runResult{}, err
+	}
+	if err := sim.LoadRocket(&orkData.Rocket, motorData); err != nil {
+		return runResult{}, err
+	}
+	if err := sim.Run(); err != nil {
+		return runResult{}, err
+	}
+
+	// Hash the complete record, so the file must be flushed and closed first
+	if err := storage.Close(); err != nil {
+		return runResult{}, err
+	}
+	hash, err := hashFile(storage.GetFilePath())
+	if err != nil {
+		return runResult{}, err
+	}
+
+	stats := sim.GetStats()
+	return runResult{
+		recordHash: hash,
+		metrics: map[string]float64{
+			"apogee":              stats.Apogee,
+			"time_to_apogee":      stats.TimeToApogee,
+			"max_velocity":        stats.MaxVelocity,
+			"max_accel":           stats.MaxAccel,
+			"max_mach":            stats.MaxMach,
+			"ground_hit_velocity": stats.GroundHitVelocity,
+			"total_flight_time":   stats.TotalFlightTime,
+		},
+	}, nil
+}
+
+// hashFile returns the hex SHA-256 of a file's contents
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -1,18 +1,44 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/bxrne/launchrail/internal/config"
 	"github.com/bxrne/launchrail/internal/http_client"
 	"github.com/bxrne/launchrail/internal/logger"
-	"github.com/bxrne/launchrail/internal/storage"
+	storagepkg "github.com/bxrne/launchrail/internal/storage"
+	"github.com/bxrne/launchrail/internal/webhook"
+	"github.com/bxrne/launchrail/pkg/designation"
 	"github.com/bxrne/launchrail/pkg/openrocket"
 	"github.com/bxrne/launchrail/pkg/simulation"
 	"github.com/bxrne/launchrail/pkg/thrustcurves"
+	"github.com/zerodha/logf"
+)
+
+// webhookTimeout bounds webhook delivery including retries, so the run never waits on it longer
+const webhookTimeout = time.Minute
+
+// Build provenance, set with -ldflags "-X main.version=<version> -X main.commit=<commit>"
+var (
+	version = "dev"
+	commit  = "unknown"
 )
 
 func main() {
+	verify := flag.Bool("verify-determinism", false, "run the simulation twice and fail if the results differ")
+	printVersion := flag.Bool("version", false, "print the build version and commit")
+	flag.Parse()
+
+	if *printVersion {
+		fmt.Printf("launchrail %s (%s)\n", version, commit)
+		return
+	}
+
 	// Load config
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -22,48 +48,95 @@ func main() {
 
 	// Initialize logger
 	log := logger.GetLogger(cfg)
-	log.Info("Config loaded", "Name", cfg.App.Name, "Version", cfg.App.Version)
+	log.Info("Config loaded", "Name", cfg.App.Name, "Version", cfg.App.Version, "Build", version, "Commit", commit)
+
+	// Strict mode has already failed validation, otherwise an inconsistent atmosphere only biases results
+	if err := cfg.Options.Launchsite.Atmosphere.ISAConfiguration.CheckConsistency(); err != nil {
+		log.Warn("ISA configuration is inconsistent", "Error", err)
+	}
+	if lapseRate := cfg.Options.Launchsite.Atmosphere.ISAConfiguration.TemperatureLapseRate; lapseRate < 0 {
+		log.Warn("A negative temperature_lapse_rate is deprecated, configure the positive rate temperature falls with altitude", "Value", lapseRate)
+	}
 
 	// Load motor data
-	motorData, err := thrustcurves.Load(cfg.Options.MotorDesignation, http_client.NewHTTPClient())
+	motorData, err := loadMotorData(cfg, log)
 	if err != nil {
 		log.Fatal("Failed to load motor data", "Error", err)
 	}
+	if cfg.Options.SmoothThrustTail {
+		motorData.Thrust = thrustcurves.SmoothTail(motorData.Thrust)
+	}
 	log.Debug("Motor data loaded", "Designation", motorData.Designation, "TotalMass", motorData.TotalMass)
 
 	// Load OpenRocket data
-	orkData, err := openrocket.Load(cfg.Options.OpenRocketFile, cfg.External.OpenRocketVersion)
+	orkData, err := loadOpenRocket(cfg, log)
 	if err != nil {
 		log.Fatal("Failed to load OpenRocket data", "Error", err)
 	}
-	log.Debug("OpenRocket data loaded", "Version", orkData.Version, "Creator", orkData.Creator)
+	log.Debug("OpenRocket data loaded", "Version", orkData.Version, "Creator", orkData.Creator, "Detected", orkData.DetectedVersion())
 
-	// Initialize storage with headers
-	storage, err := storage.NewStorage(cfg.App.BaseDir, "motion")
-	if err != nil {
-		log.Fatal("Failed to create storage", "error", err)
+	if *verify {
+		diverged, err := verifyDeterminism(cfg, log, motorData, orkData)
+		if err != nil {
+			log.Fatal("Determinism verification failed", "Error", err)
+		}
+		for _, d := range diverged {
+			log.Error("Diverged", "value", d)
+		}
+		if len(diverged) > 0 {
+			log.Fatal("Simulation is not deterministic", "diverged", len(diverged))
+		}
+		log.Info("Simulation is deterministic")
+		return
 	}
-	defer storage.Close()
 
-	// Set headers for storage of motion data
-	err = storage.Init([]string{
-		"time",
-		"altitude",     // Changed from position_y for clarity
-		"velocity",     // Changed from velocity_y for clarity
-		"acceleration", // Changed from acceleration_y for clarity
-		"thrust",
-	})
-	if err != nil {
-		log.Fatal("Failed to init storage", "error", err)
+	// Prune old records before writing a new one
+	if r := cfg.Storage.Retention; r.Enabled {
+		removed, err := storagepkg.EnforceRetention(cfg.App.BaseDir, "motion", storagepkg.RetentionPolicy{
+			MaxAge:   r.MaxAge,
+			MaxCount: r.MaxCount,
+			MaxBytes: r.MaxBytes,
+			DryRun:   r.DryRun,
+		})
+		if err != nil {
+			log.Fatal("Failed to enforce retention", "error", err)
+		}
+		for _, path := range removed {
+			log.Info("Record pruned", "path", path, "dry_run", r.DryRun)
+		}
 	}
 
-	// Configure logger with additional debug level
-	log.Debug("Storage initialized",
-		"path", storage.GetFilePath(),
-		"headers", fmt.Sprintf("%v", []string{"time", "altitude", "velocity", "acceleration", "thrust"}),
-	)
+	// Initialize storage with headers, a nil store isn't written
+	var storage storagepkg.Store
+	if cfg.Storage.DisableMotion {
+		log.Debug("Storage for motion data disabled")
+	} else {
+		storage, err = storagepkg.New(cfg.Storage.Backend, cfg.App.BaseDir, "motion", storagepkg.S3Options{
+			Bucket:   cfg.Storage.S3.Bucket,
+			Region:   cfg.Storage.S3.Region,
+			Prefix:   cfg.Storage.S3.Prefix,
+			Endpoint: cfg.Storage.S3.Endpoint,
+		})
+		if err != nil {
+			log.Fatal("Failed to create storage", "error", err)
+		}
+		defer storage.Close()
+
+		// Set headers for storage of motion data
+		headers := motionHeaders(cfg)
+		err = storage.Init(headers)
+		if err != nil {
+			log.Fatal("Failed to init storage", "error", err)
+		}
+
+		// Configure logger with additional debug level
+		log.Debug("Storage initialized",
+			"path", storage.GetFilePath(),
+			"headers", fmt.Sprintf("%v", headers),
+		)
 
-	log.Debug("Storage for motion data initialized", "BaseDir", cfg.App.BaseDir)
+		log.Debug("Storage for motion data initialized", "BaseDir", cfg.App.BaseDir)
+	}
 
 	// Create simulation
 	sim, err := simulation.NewSimulation(cfg, log, storage)
@@ -85,6 +158,98 @@ func main() {
 		log.Fatal("Simulation failed", "Error", err)
 	}
 
+	// Complete the record before it is read back or announced, the deferred Close then does nothing
+	if storage != nil {
+		if err := storage.Close(); err != nil {
+			log.Fatal("Failed to close storage", "error", err)
+		}
+	}
+
+	// Derive a noisy sensor view from the clean motion data
+	if se := cfg.Storage.SensorEmulation; se.Enabled {
+		sensorPath, err := storagepkg.EmulateSensor(storage.GetFilePath(), se.Seed, []storagepkg.SensorNoise{
+			{Column: "altitude", StdDev: se.AltitudeNoise, Quantum: se.AltitudeQuantization},
+			{Column: "velocity", StdDev: se.VelocityNoise, Quantum: se.VelocityQuantization},
+		})
+		if err != nil {
+			log.Fatal("Failed to emulate sensor data", "error", err)
+		}
+		log.Debug("Sensor view saved", "Path", sensorPath)
+	}
+
 	log.Info("Simulation completed successfully")
-	log.Debug("Simulation data saved", "Path", storage.GetFilePath())
+	if storage != nil {
+		log.Debug("Simulation data saved", "Path", storage.GetFilePath())
+	}
+
+	// Notify the completion webhook, waiting at most webhookTimeout. Failures don't fail the run
+	if hook := cfg.Server.CompletionWebhook; hook.URL != "" {
+		flightStats := sim.GetStats()
+		payload := webhook.Payload{
+			Apogee:       flightStats.Apogee,
+			TimeToApogee: flightStats.TimeToApogee,
+			MaxVelocity:  flightStats.MaxVelocity,
+			MaxAccel:     flightStats.MaxAccel,
+			MaxMach:      flightStats.MaxMach,
+		}
+		if storage != nil {
+			payload.Record = filepath.Base(storage.GetFilePath())
+			payload.RecordHash = storage.Hash()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+		defer cancel()
+		if err := webhook.Notify(ctx, http_client.NewHTTPClient(), hook.URL, hook.Secret, payload, hook.Retries); err != nil {
+			log.Error("Completion webhook failed", "URL", hook.URL, "Error", err)
+		} else {
+			log.Debug("Completion webhook delivered", "URL", hook.URL)
+		}
+	}
+}
+
+// loadOpenRocket loads the design file, warning rather than failing on a version mismatch unless strict
+func loadOpenRocket(cfg *config.Config, log *logf.Logger) (*openrocket.OpenrocketDocument, error) {
+	if cfg.External.OpenRocketVersion == "auto" {
+		return openrocket.LoadAnyVersion(cfg.Options.OpenRocketFile)
+	}
+
+	orkData, err := openrocket.Load(cfg.Options.OpenRocketFile, cfg.External.OpenRocketVersion)
+	var mismatch *openrocket.VersionMismatchError
+	if errors.As(err, &mismatch) && !cfg.External.StrictOpenRocketVersion {
+		log.Warn("OpenRocket version mismatch, parsing may misbehave", "Configured", mismatch.Configured, "Detected", mismatch.Detected)
+		return openrocket.LoadAnyVersion(cfg.Options.OpenRocketFile)
+	}
+	return orkData, err
+}
+
+// motionHeaders returns the columns of the motion store
+func motionHeaders(cfg *config.Config) []string {
+	headers := []string{
+		"time",
+		"altitude",     // Changed from position_y for clarity
+		"velocity",     // Changed from velocity_y for clarity
+		"acceleration", // Changed from acceleration_y for clarity
+		"thrust",
+	}
+	if cfg.Storage.AccelerationG {
+		headers = append(headers, "acceleration_g")
+	}
+	return headers
+}
+
+// loadMotorData prefers the motor packed in the OpenRocket file when enabled, falling back to the ThrustCurve API
+func loadMotorData(cfg *config.Config, log *logf.Logger) (*thrustcurves.MotorData, error) {
+	if cfg.Options.UseEmbeddedMotor {
+		data, err := openrocket.ExtractEmbeddedMotor(cfg.Options.OpenRocketFile)
+		if err == nil {
+			des, err := designation.New(cfg.Options.MotorDesignation)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create motor designation: %s", err)
+			}
+			return thrustcurves.ParseRASP(data, des)
+		}
+		log.Warn("Embedded motor unavailable, using ThrustCurve", "Error", err)
+	}
+
+	return thrustcurves.Load(cfg.Options.MotorDesignation, http_client.NewHTTPClient())
 }
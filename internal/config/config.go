@@ -2,7 +2,10 @@ package config
 
 import (
 	"fmt"
+	"math"
+	"net/url"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/viper"
 )
@@ -12,6 +15,9 @@ var (
 )
 
 // GetConfig returns the application configuration as a singleton
+// NOTE: If the base config sets `profile`, that file is deep-merged over the base.
+// Keys present in the profile win, everything else falls through to the base.
+// The profile path is relative to the base config's directory.
 func GetConfig() (*Config, error) {
 
 	v := viper.New()
@@ -23,7 +29,11 @@ func GetConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %s", err)
 	}
 
-	if err := v.Unmarshal(&cfg); err != nil {
+	if err := mergeProfile(v); err != nil {
+		return nil, fmt.Errorf("failed to merge config profile: %s", err)
+	}
+
+	if err := v.Unmarshal(&cfg, viper.DecodeHook(decodeHook())); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %s", err)
 	}
 
@@ -34,6 +44,45 @@ func GetConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// mergeProfile merges the profile override file referenced by the base config, if any
+func mergeProfile(v *viper.Viper) error {
+	profile := v.GetString("profile")
+	if profile == "" {
+		return nil
+	}
+
+	if !filepath.IsAbs(profile) {
+		profile = filepath.Join(filepath.Dir(v.ConfigFileUsed()), profile)
+	}
+
+	v.SetConfigFile(profile)
+	return v.MergeInConfig()
+}
+
+// isaDensityTolerance is the relative density error tolerated against the ideal gas law
+const isaDensityTolerance = 0.02
+
+// CheckConsistency checks the sea level density against p/(R·T) from the other sea level fields
+func (isa ISAConfiguration) CheckConsistency() error {
+	if isa.SpecificGasConstant <= 0 || isa.SeaLevelTemperature <= 0 {
+		return fmt.Errorf("options.launchsite.atmosphere.isa_configuration gas constant and temperature must be positive")
+	}
+
+	expected := isa.SeaLevelPressure / (isa.SpecificGasConstant * isa.SeaLevelTemperature)
+	if math.Abs(isa.SeaLevelDensity-expected) > isaDensityTolerance*expected {
+		return fmt.Errorf("options.launchsite.atmosphere.isa_configuration.sea_level_density %.4f is inconsistent with p/(R·T) = %.4f", isa.SeaLevelDensity, expected)
+	}
+
+	return nil
+}
+
+// TemperatureGradient returns the change in temperature with altitude in K/m, which is negative.
+// The lapse rate is configured as the positive rate temperature falls, a negative value is
+// deprecated but read by its magnitude so either sign cools the air with altitude.
+func (isa ISAConfiguration) TemperatureGradient() float64 {
+	return -math.Abs(isa.TemperatureLapseRate)
+}
+
 // Validate checks the config to error on empty field
 func (cfg *Config) Validate() error {
 	if cfg.App.Name == "" {
@@ -68,6 +117,12 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("options.openrocket_file is invalid: %s", err)
 	}
 
+	if cfg.Options.AeroDeck != "" {
+		if _, err := os.Stat(cfg.Options.AeroDeck); err != nil {
+			return fmt.Errorf("options.aero_deck is invalid: %s", err)
+		}
+	}
+
 	if cfg.Options.Launchrail.Length == 0 {
 		return fmt.Errorf("options.launchrail.length is required")
 	}
@@ -120,6 +175,18 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("options.launchsite.atmosphere.isa_configuration.temperature_lapse_rate is required")
 	}
 
+	if cfg.Options.Launchsite.Atmosphere.StrictISA {
+		if err := cfg.Options.Launchsite.Atmosphere.ISAConfiguration.CheckConsistency(); err != nil {
+			return err
+		}
+	}
+
+	for i, p := range cfg.Options.Protrusions {
+		if p.DragArea <= 0 {
+			return fmt.Errorf("options.protrusions[%d].drag_area must be positive", i)
+		}
+	}
+
 	if cfg.Simulation.Step == 0 {
 		return fmt.Errorf("simulation.step is required")
 	}
@@ -128,5 +195,63 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("simulation.max_time is required")
 	}
 
+	if cfg.Simulation.MinThrustToWeight < 0 {
+		return fmt.Errorf("simulation.min_thrust_to_weight must not be negative")
+	}
+
+	switch cfg.Storage.Backend {
+	case "", "fs":
+	case "s3":
+		if cfg.Storage.S3.Bucket == "" {
+			return fmt.Errorf("storage.s3.bucket is required for the s3 backend")
+		}
+		// Both read the record back from local disk
+		if cfg.Storage.SensorEmulation.Enabled || cfg.Storage.Retention.Enabled {
+			return fmt.Errorf("storage.sensor_emulation and storage.retention require the fs backend")
+		}
+	default:
+		return fmt.Errorf("storage.backend must be one of fs, s3")
+	}
+
+	if cfg.Simulation.Workers < 0 {
+		return fmt.Errorf("simulation.workers must not be negative")
+	}
+
+	if h := cfg.Simulation.EventHysteresis; h.MinTime < 0 || h.MinAltitude < 0 {
+		return fmt.Errorf("simulation.event_hysteresis thresholds must not be negative")
+	}
+
+	if cfg.Storage.DisableMotion && cfg.Storage.SensorEmulation.Enabled {
+		return fmt.Errorf("storage.sensor_emulation requires the motion store")
+	}
+
+	if cfg.Storage.Precision < 0 {
+		return fmt.Errorf("storage.precision must not be negative")
+	}
+
+	if se := cfg.Storage.SensorEmulation; se.AltitudeNoise < 0 || se.AltitudeQuantization < 0 || se.VelocityNoise < 0 || se.VelocityQuantization < 0 {
+		return fmt.Errorf("storage.sensor_emulation noise and quantization must not be negative")
+	}
+
+	if r := cfg.Storage.Retention; r.MaxAge < 0 || r.MaxCount < 0 || r.MaxBytes < 0 {
+		return fmt.Errorf("storage.retention limits must not be negative")
+	}
+
+	switch cfg.Reporting.ApogeeMethod {
+	case "", "max", "velocity", "smoothed":
+	default:
+		return fmt.Errorf("reporting.apogee_method must be one of max, velocity, smoothed")
+	}
+
+	if cfg.Server.CompletionWebhook.URL != "" {
+		if _, err := url.ParseRequestURI(cfg.Server.CompletionWebhook.URL); err != nil {
+			return fmt.Errorf("server.completion_webhook.url is invalid: %s", err)
+		}
+	}
+
+	if cfg.Server.CompletionWebhook.Retries < 0 {
+		return fmt.Errorf("server.completion_webhook.retries must not be negative")
+	}
+
 	return nil
 }
@@ -1,10 +1,13 @@
 package config_test
 
 import (
+	"math"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/bxrne/launchrail/internal/config"
+	"github.com/bxrne/launchrail/pkg/atmosphere"
 )
 
 // Helper to change directory and reset after test
@@ -550,3 +553,211 @@ func TestGetConfigMissingISAConfigurationTemperatureLapseRate(t *testing.T) {
 		}
 	})
 }
+
+// TEST: GIVEN a base config referencing a profile WHEN GetConfig is called THEN the profile overrides the base
+func TestGetConfigProfile(t *testing.T) {
+	withWorkingDir(t, "../../testdata/config/profile", func(cfg *config.Config, err error) {
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+
+		if cfg.Options.MotorDesignation != "G80-7T" {
+			t.Errorf("Expected profile motor designation G80-7T, got %s", cfg.Options.MotorDesignation)
+		}
+
+		if cfg.Options.Launchrail.Length != 1.5 {
+			t.Errorf("Expected profile launchrail length 1.5, got %.2f", cfg.Options.Launchrail.Length)
+		}
+
+		if cfg.Options.Launchrail.Angle != 5.0 {
+			t.Errorf("Expected base launchrail angle 5.0, got %.2f", cfg.Options.Launchrail.Angle)
+		}
+	})
+}
+
+// TEST: GIVEN a config with an invalid server.completion_webhook.url WHEN Validate is called THEN an error is returned
+func TestGetConfigInvalidWebhookURL(t *testing.T) {
+	withWorkingDir(t, "../..", func(cfg *config.Config, err error) {
+		if err != nil {
+			t.Errorf("Expected no error, got: %s", err)
+		}
+
+		cfg.Server.CompletionWebhook.URL = "not a url"
+		defer func() { cfg.Server.CompletionWebhook.URL = "" }()
+		err = cfg.Validate()
+		if err == nil {
+			t.Error("Expected an error, got nil")
+		}
+
+		expected := "server.completion_webhook.url is invalid:"
+		if err.Error()[:len(expected)] != expected {
+			t.Errorf("Expected %s, got %s", expected, err)
+		}
+	})
+}
+
+// TEST: GIVEN an ISA configuration WHEN CheckConsistency is called THEN density is checked against the gas law
+func TestISAConfigurationCheckConsistency(t *testing.T) {
+	isa := config.ISAConfiguration{
+		SpecificGasConstant: 287.05,
+		SeaLevelDensity:     1.225,
+		SeaLevelTemperature: 288.15,
+		SeaLevelPressure:    101325,
+	}
+	if err := isa.CheckConsistency(); err != nil {
+		t.Errorf("Expected no error, got: %s", err)
+	}
+
+	// Density copied from a hot day profile with standard pressure and temperature
+	isa.SeaLevelDensity = 1.112
+	if err := isa.CheckConsistency(); err == nil {
+		t.Error("Expected an error, got nil")
+	}
+}
+
+// TEST: GIVEN a strict_isa config with inconsistent density WHEN Validate is called THEN an error is returned
+func TestGetConfigStrictISA(t *testing.T) {
+	withWorkingDir(t, "../..", func(cfg *config.Config, err error) {
+		if err != nil {
+			t.Errorf("Expected no error, got: %s", err)
+		}
+
+		atmosphere := &cfg.Options.Launchsite.Atmosphere
+		density := atmosphere.ISAConfiguration.SeaLevelDensity
+		defer func() {
+			atmosphere.StrictISA = false
+			atmosphere.ISAConfiguration.SeaLevelDensity = density
+		}()
+
+		atmosphere.ISAConfiguration.SeaLevelDensity = 1.112
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected only a warning without strict_isa, got: %s", err)
+		}
+
+		atmosphere.StrictISA = true
+		err = cfg.Validate()
+		if err == nil {
+			t.Error("Expected an error, got nil")
+		}
+
+		expected := "options.launchsite.atmosphere.isa_configuration.sea_level_density 1.1120 is inconsistent"
+		if err.Error()[:len(expected)] != expected {
+			t.Errorf("Expected %s, got %s", expected, err)
+		}
+	})
+}
+
+// TEST: GIVEN a config with feet suffixes WHEN GetConfig is called THEN the values are converted to metres
+func TestGetConfigUnits(t *testing.T) {
+	withWorkingDir(t, "../../testdata/config/units", func(cfg *config.Config, err error) {
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+
+		if math.Abs(float64(cfg.Options.Launchrail.Length)-1.8288) > 1e-9 {
+			t.Errorf("Expected launchrail length 1.8288, got %f", cfg.Options.Launchrail.Length)
+		}
+
+		if math.Abs(float64(cfg.Options.Launchsite.Altitude)-365.76) > 1e-9 {
+			t.Errorf("Expected launchsite altitude 365.76, got %f", cfg.Options.Launchsite.Altitude)
+		}
+
+		if cfg.Options.Launchrail.Angle != 5.0 {
+			t.Errorf("Expected plain launchrail angle 5.0, got %.2f", cfg.Options.Launchrail.Angle)
+		}
+	})
+}
+
+// TEST: GIVEN a config with an unknown unit WHEN GetConfig is called THEN the error 'failed to unmarshal config' is returned
+func TestGetConfigUnknownUnit(t *testing.T) {
+	withWorkingDir(t, "../../testdata/config/bad_units", func(cfg *config.Config, err error) {
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+
+		expected := "failed to unmarshal config"
+		if err.Error()[:len(expected)] != expected {
+			t.Errorf("Expected %s, got %s", expected, err)
+		}
+	})
+}
+
+// TEST: GIVEN a config with an unknown storage.backend WHEN Validate is called THEN an error is returned
+func TestGetConfigUnsupportedStorageBackend(t *testing.T) {
+	withWorkingDir(t, "../..", func(cfg *config.Config, err error) {
+		if err != nil {
+			t.Errorf("Expected no error, got: %s", err)
+		}
+
+		cfg.Storage.Backend = "ftp"
+		defer func() { cfg.Storage.Backend = "fs" }()
+		err = cfg.Validate()
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+
+		expected := "storage.backend must be one of fs, s3"
+		if err.Error() != expected {
+			t.Errorf("Expected %s, got %s", expected, err)
+		}
+	})
+}
+
+// TEST: GIVEN the s3 storage.backend WHEN Validate is called THEN a bucket is required
+func TestGetConfigS3StorageBackend(t *testing.T) {
+	withWorkingDir(t, "../..", func(cfg *config.Config, err error) {
+		if err != nil {
+			t.Errorf("Expected no error, got: %s", err)
+		}
+
+		cfg.Storage.Backend = "s3"
+		defer func() { cfg.Storage.Backend, cfg.Storage.S3.Bucket = "fs", "" }()
+		err = cfg.Validate()
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+
+		expected := "storage.s3.bucket is required for the s3 backend"
+		if err.Error() != expected {
+			t.Errorf("Expected %s, got %s", expected, err)
+		}
+
+		cfg.Storage.S3.Bucket = "records"
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected no error, got: %s", err)
+		}
+	})
+}
+
+// TEST: GIVEN a config with a unit on a field that is not a length WHEN GetConfig is called THEN the error 'failed to unmarshal config' is returned
+func TestGetConfigUnitOnNonLength(t *testing.T) {
+	withWorkingDir(t, "../../testdata/config/bad_unit_field", func(cfg *config.Config, err error) {
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+
+		expected := "failed to unmarshal config"
+		if err.Error()[:len(expected)] != expected {
+			t.Errorf("Expected %s, got %s", expected, err)
+		}
+
+		if !strings.Contains(err.Error(), "only accepted on lengths") {
+			t.Errorf("Expected the unit to be rejected, got %s", err)
+		}
+	})
+}
+
+// TEST: GIVEN the shipped config WHEN the ISA temperature is computed aloft THEN it is colder than at sea level
+func TestGetConfigShippedAtmosphereCoolsWithAltitude(t *testing.T) {
+	withWorkingDir(t, "../..", func(cfg *config.Config, err error) {
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+
+		isa := cfg.Options.Launchsite.Atmosphere.ISAConfiguration
+		temperature := atmosphere.NewISAModel(&isa).GetTemperature(1000)
+		if temperature >= isa.SeaLevelTemperature {
+			t.Errorf("Expected below %v K at 1000 m, got %v K", isa.SeaLevelTemperature, temperature)
+		}
+	})
+}
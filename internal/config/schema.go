@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // App represents the application configuration.
 type App struct {
@@ -16,12 +19,13 @@ type Logging struct {
 
 // External represents the external configuration.
 type External struct {
-	OpenRocketVersion string `mapstructure:"openrocket_version"`
+	OpenRocketVersion       string `mapstructure:"openrocket_version"`        // "auto" accepts whichever version wrote the file
+	StrictOpenRocketVersion bool   `mapstructure:"strict_openrocket_version"` // Fail rather than warn on a version mismatch
 }
 
 // Launchrail represents the launchrail configuration.
 type Launchrail struct {
-	Length      float64 `mapstructure:"length"`
+	Length      Length  `mapstructure:"length"`
 	Angle       float64 `mapstructure:"angle"`
 	Orientation float64 `mapstructure:"orientation"`
 }
@@ -30,13 +34,15 @@ type Launchrail struct {
 type Launchsite struct {
 	Latitude   float64    `mapstructure:"latitude"`
 	Longitude  float64    `mapstructure:"longitude"`
-	Altitude   float64    `mapstructure:"altitude"`
+	Altitude   Length     `mapstructure:"altitude"`
 	Atmosphere Atmosphere `mapstructure:"atmosphere"`
 }
 
 // Atmosphere represents the atmosphere configuration.
 type Atmosphere struct {
 	ISAConfiguration ISAConfiguration `mapstructure:"isa_configuration"`
+	StrictISA        bool             `mapstructure:"strict_isa"` // Fail rather than warn on a physically inconsistent ISA configuration
+	Precompute       bool             `mapstructure:"precompute"` // Interpolate a table built at start rather than computing every step
 }
 
 // ISAConfiguration represents the ISA configuration.
@@ -50,18 +56,96 @@ type ISAConfiguration struct {
 	TemperatureLapseRate float64 `mapstructure:"temperature_lapse_rate"`
 }
 
+// Protrusion represents a parasitic drag source such as a rail button, launch lug or camera shroud.
+type Protrusion struct {
+	Name     string  `mapstructure:"name"`
+	DragArea float64 `mapstructure:"drag_area"` // Cd·A in m²
+}
+
 // Options represents the application options.
 type Options struct {
-	MotorDesignation string     `mapstructure:"motor_designation"`
-	OpenRocketFile   string     `mapstructure:"openrocket_file"`
-	Launchrail       Launchrail `mapstructure:"launchrail"`
-	Launchsite       Launchsite `mapstructure:"launchsite"`
+	MotorDesignation string       `mapstructure:"motor_designation"`
+	OpenRocketFile   string       `mapstructure:"openrocket_file"`
+	UseEmbeddedMotor bool         `mapstructure:"use_embedded_motor"` // Use the .eng packed in the OpenRocket file if present
+	SmoothThrustTail bool         `mapstructure:"smooth_thrust_tail"` // Moving average the curve after peak thrust
+	StrictMotorFit   bool         `mapstructure:"strict_motor_fit"`   // Fail rather than warn when the motor doesn't fit its mount
+	AeroDeck         string       `mapstructure:"aero_deck"`          // CSV of Mach, Cd, CP, CNα overriding the internal aero when set
+	Launchrail       Launchrail   `mapstructure:"launchrail"`
+	Launchsite       Launchsite   `mapstructure:"launchsite"`
+	Protrusions      []Protrusion `mapstructure:"protrusions"`
+}
+
+// EventHysteresis represents the debouncing of flight events.
+type EventHysteresis struct {
+	MinTime     float64 `mapstructure:"min_time"`     // Minimum seconds between events
+	MinAltitude Length  `mapstructure:"min_altitude"` // Minimum drop in m below max altitude before apogee fires
 }
 
 // Simulation represents the simulation configuration.
 type Simulation struct {
-	Step    float64 `mapstructure:"step"`
-	MaxTime float64 `mapstructure:"max_time"`
+	Step              float64         `mapstructure:"step"`
+	MaxTime           float64         `mapstructure:"max_time"`
+	EmitMaxEvents     bool            `mapstructure:"emit_max_events"`
+	MinThrustToWeight float64         `mapstructure:"min_thrust_to_weight"` // Liftoff T/W below which the run aborts, 0 defaults to 1
+	EventHysteresis   EventHysteresis `mapstructure:"event_hysteresis"`
+	Workers           int             `mapstructure:"workers"`        // Physics worker goroutines across entities, 0 defaults to 4
+	StopAtApogee      bool            `mapstructure:"stop_at_apogee"` // End the run at apogee for ascent-only studies
+	StrictStep        bool            `mapstructure:"strict_step"`    // Fail rather than warn when the step can't resolve the thrust curve
+}
+
+// SensorEmulation represents the noisy "sensor view" store configuration.
+type SensorEmulation struct {
+	Enabled              bool    `mapstructure:"enabled"`
+	Seed                 int64   `mapstructure:"seed"`
+	AltitudeNoise        float64 `mapstructure:"altitude_noise"`        // Standard deviation in m
+	AltitudeQuantization float64 `mapstructure:"altitude_quantization"` // Step in m, 0 to disable
+	VelocityNoise        float64 `mapstructure:"velocity_noise"`        // Standard deviation in m/s
+	VelocityQuantization float64 `mapstructure:"velocity_quantization"` // Step in m/s, 0 to disable
+}
+
+// Retention represents the stored record retention policy, enforced on startup.
+type Retention struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	MaxAge   time.Duration `mapstructure:"max_age"`   // 0 to disable
+	MaxCount int           `mapstructure:"max_count"` // 0 to disable
+	MaxBytes int64         `mapstructure:"max_bytes"` // 0 to disable
+	DryRun   bool          `mapstructure:"dry_run"`
+}
+
+// S3 represents the bucket used by the s3 storage backend, credentials come from the default AWS chain.
+type S3 struct {
+	Bucket   string `mapstructure:"bucket"`
+	Region   string `mapstructure:"region"`
+	Prefix   string `mapstructure:"prefix"`
+	Endpoint string `mapstructure:"endpoint"` // For S3 compatible services, empty uses AWS
+}
+
+// Storage represents the storage configuration.
+type Storage struct {
+	Backend         string          `mapstructure:"backend"`   // fs or s3, defaults to fs
+	Precision       int             `mapstructure:"precision"` // Significant figures for stored values, 0 keeps full precision
+	AccelerationG   bool            `mapstructure:"acceleration_g"`
+	DisableMotion   bool            `mapstructure:"disable_motion"` // Skip the motion store for runs that only need summary stats
+	SensorEmulation SensorEmulation `mapstructure:"sensor_emulation"`
+	Retention       Retention       `mapstructure:"retention"`
+	S3              S3              `mapstructure:"s3"`
+}
+
+// Reporting represents the reporting configuration.
+type Reporting struct {
+	ApogeeMethod string `mapstructure:"apogee_method"` // max, velocity or smoothed
+}
+
+// Server represents the configuration for integrating runs with other services.
+type Server struct {
+	CompletionWebhook Webhook `mapstructure:"completion_webhook"`
+}
+
+// Webhook represents the completion webhook configuration.
+type Webhook struct {
+	URL     string `mapstructure:"url"`
+	Secret  string `mapstructure:"secret"`
+	Retries int    `mapstructure:"retries"`
 }
 
 // Config represents the overall application configuration.
@@ -71,6 +155,9 @@ type Config struct {
 	External   External   `mapstructure:"external"`
 	Options    Options    `mapstructure:"options"`
 	Simulation Simulation `mapstructure:"simulation"`
+	Storage    Storage    `mapstructure:"storage"`
+	Reporting  Reporting  `mapstructure:"reporting"`
+	Server     Server     `mapstructure:"server"`
 }
 
 // String returns the configuration as a map of strings, useful for testing.
@@ -81,8 +168,13 @@ func (c *Config) String() map[string]string {
 	marshalled["logging.level"] = c.Logging.Level
 	marshalled["app.base_dir"] = c.App.BaseDir
 	marshalled["external.openrocket_version"] = c.External.OpenRocketVersion
+	marshalled["external.strict_openrocket_version"] = fmt.Sprintf("%t", c.External.StrictOpenRocketVersion)
 	marshalled["options.motor_designation"] = c.Options.MotorDesignation
 	marshalled["options.openrocket_file"] = c.Options.OpenRocketFile
+	marshalled["options.use_embedded_motor"] = fmt.Sprintf("%t", c.Options.UseEmbeddedMotor)
+	marshalled["options.smooth_thrust_tail"] = fmt.Sprintf("%t", c.Options.SmoothThrustTail)
+	marshalled["options.strict_motor_fit"] = fmt.Sprintf("%t", c.Options.StrictMotorFit)
+	marshalled["options.aero_deck"] = c.Options.AeroDeck
 	marshalled["options.launchrail.length"] = fmt.Sprintf("%.2f", c.Options.Launchrail.Length)
 	marshalled["options.launchrail.angle"] = fmt.Sprintf("%.2f", c.Options.Launchrail.Angle)
 	marshalled["options.launchrail.orientation"] = fmt.Sprintf("%.2f", c.Options.Launchrail.Orientation)
@@ -95,9 +187,39 @@ func (c *Config) String() map[string]string {
 	marshalled["options.launchsite.atmosphere.isa_configuration.sea_level_temperature"] = fmt.Sprintf("%.2f", c.Options.Launchsite.Atmosphere.ISAConfiguration.SeaLevelTemperature)
 	marshalled["options.launchsite.atmosphere.isa_configuration.sea_level_pressure"] = fmt.Sprintf("%.2f", c.Options.Launchsite.Atmosphere.ISAConfiguration.SeaLevelPressure)
 	marshalled["options.launchsite.atmosphere.isa_configuration.ratio_specific_heats"] = fmt.Sprintf("%.2f", c.Options.Launchsite.Atmosphere.ISAConfiguration.RatioSpecificHeats)
+	marshalled["options.launchsite.atmosphere.strict_isa"] = fmt.Sprintf("%t", c.Options.Launchsite.Atmosphere.StrictISA)
+	marshalled["options.launchsite.atmosphere.precompute"] = fmt.Sprintf("%t", c.Options.Launchsite.Atmosphere.Precompute)
 	marshalled["options.launchsite.atmosphere.isa_configuration.temperature_lapse_rate"] = fmt.Sprintf("%.2f", c.Options.Launchsite.Atmosphere.ISAConfiguration.TemperatureLapseRate)
+	for i, p := range c.Options.Protrusions {
+		marshalled[fmt.Sprintf("options.protrusions.%d.name", i)] = p.Name
+		marshalled[fmt.Sprintf("options.protrusions.%d.drag_area", i)] = fmt.Sprintf("%.6f", p.DragArea)
+	}
 	marshalled["simulation.step"] = fmt.Sprintf("%.2f", c.Simulation.Step)
 	marshalled["simulation.max_time"] = fmt.Sprintf("%.2f", c.Simulation.MaxTime)
+	marshalled["simulation.emit_max_events"] = fmt.Sprintf("%t", c.Simulation.EmitMaxEvents)
+	marshalled["simulation.min_thrust_to_weight"] = fmt.Sprintf("%.2f", c.Simulation.MinThrustToWeight)
+	marshalled["simulation.workers"] = fmt.Sprintf("%d", c.Simulation.Workers)
+	marshalled["simulation.stop_at_apogee"] = fmt.Sprintf("%t", c.Simulation.StopAtApogee)
+	marshalled["simulation.strict_step"] = fmt.Sprintf("%t", c.Simulation.StrictStep)
+	marshalled["simulation.event_hysteresis.min_time"] = fmt.Sprintf("%.2f", c.Simulation.EventHysteresis.MinTime)
+	marshalled["simulation.event_hysteresis.min_altitude"] = fmt.Sprintf("%.2f", c.Simulation.EventHysteresis.MinAltitude)
+	marshalled["storage.backend"] = c.Storage.Backend
+	marshalled["storage.disable_motion"] = fmt.Sprintf("%t", c.Storage.DisableMotion)
+	marshalled["storage.precision"] = fmt.Sprintf("%d", c.Storage.Precision)
+	marshalled["storage.acceleration_g"] = fmt.Sprintf("%t", c.Storage.AccelerationG)
+	marshalled["storage.sensor_emulation.enabled"] = fmt.Sprintf("%t", c.Storage.SensorEmulation.Enabled)
+	marshalled["storage.retention.enabled"] = fmt.Sprintf("%t", c.Storage.Retention.Enabled)
+	marshalled["storage.retention.max_age"] = c.Storage.Retention.MaxAge.String()
+	marshalled["storage.retention.max_count"] = fmt.Sprintf("%d", c.Storage.Retention.MaxCount)
+	marshalled["storage.retention.max_bytes"] = fmt.Sprintf("%d", c.Storage.Retention.MaxBytes)
+	marshalled["storage.retention.dry_run"] = fmt.Sprintf("%t", c.Storage.Retention.DryRun)
+	marshalled["storage.s3.bucket"] = c.Storage.S3.Bucket
+	marshalled["storage.s3.region"] = c.Storage.S3.Region
+	marshalled["storage.s3.prefix"] = c.Storage.S3.Prefix
+	marshalled["storage.s3.endpoint"] = c.Storage.S3.Endpoint
+	marshalled["reporting.apogee_method"] = c.Reporting.ApogeeMethod
+	marshalled["server.completion_webhook.url"] = c.Server.CompletionWebhook.URL
+	marshalled["server.completion_webhook.retries"] = fmt.Sprintf("%d", c.Server.CompletionWebhook.Retries)
 
 	return marshalled
 }
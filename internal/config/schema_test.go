@@ -53,19 +53,24 @@ func TestConfigString(t *testing.T) {
 	}
 
 	expected := map[string]string{
-		"app.name":                       "launchrail-test",
-		"app.version":                    "0.0.0",
-		"app.base_dir":                   "/tmp",
-		"logging.level":                  "info",
-		"external.openrocket_version":    "15.03",
-		"options.motor_designation":      "G80-7T",
-		"options.openrocket_file":        "test/fixtures/rocket.ork",
-		"options.launchrail.length":      "0.00",
-		"options.launchrail.angle":       "0.00",
-		"options.launchrail.orientation": "0.00",
-		"options.launchsite.latitude":    "0.00",
-		"options.launchsite.longitude":   "0.00",
-		"options.launchsite.altitude":    "0.00",
+		"app.name":                           "launchrail-test",
+		"app.version":                        "0.0.0",
+		"app.base_dir":                       "/tmp",
+		"logging.level":                      "info",
+		"external.openrocket_version":        "15.03",
+		"external.strict_openrocket_version": "false",
+		"options.motor_designation":          "G80-7T",
+		"options.openrocket_file":            "test/fixtures/rocket.ork",
+		"options.use_embedded_motor":         "false",
+		"options.smooth_thrust_tail":         "false",
+		"options.strict_motor_fit":           "false",
+		"options.aero_deck":                  "",
+		"options.launchrail.length":          "0.00",
+		"options.launchrail.angle":           "0.00",
+		"options.launchrail.orientation":     "0.00",
+		"options.launchsite.latitude":        "0.00",
+		"options.launchsite.longitude":       "0.00",
+		"options.launchsite.altitude":        "0.00",
 		"options.launchsite.atmosphere.isa_configuration.specific_gas_constant":  "287.05",
 		"options.launchsite.atmosphere.isa_configuration.gravitational_accel":    "9.81",
 		"options.launchsite.atmosphere.isa_configuration.sea_level_density":      "1.225",
@@ -73,8 +78,34 @@ func TestConfigString(t *testing.T) {
 		"options.launchsite.atmosphere.isa_configuration.sea_level_pressure":     "101325.00",
 		"options.launchsite.atmosphere.isa_configuration.ratio_specific_heats":   "1.40",
 		"options.launchsite.atmosphere.isa_configuration.temperature_lapse_rate": "-0.01",
-		"simulation.step":     "0.00",
-		"simulation.max_time": "0.00",
+		"options.launchsite.atmosphere.strict_isa":                               "false",
+		"options.launchsite.atmosphere.precompute":                               "false",
+		"simulation.step":                          "0.00",
+		"simulation.max_time":                      "0.00",
+		"simulation.emit_max_events":               "false",
+		"simulation.min_thrust_to_weight":          "0.00",
+		"simulation.workers":                       "0",
+		"simulation.stop_at_apogee":                "false",
+		"simulation.strict_step":                   "false",
+		"simulation.event_hysteresis.min_time":     "0.00",
+		"simulation.event_hysteresis.min_altitude": "0.00",
+		"storage.backend":                          "",
+		"storage.precision":                        "0",
+		"storage.acceleration_g":                   "false",
+		"storage.disable_motion":                   "false",
+		"storage.sensor_emulation.enabled":         "false",
+		"storage.retention.enabled":                "false",
+		"storage.retention.max_age":                "0s",
+		"storage.retention.max_count":              "0",
+		"storage.retention.max_bytes":              "0",
+		"storage.retention.dry_run":                "false",
+		"storage.s3.bucket":                        "",
+		"storage.s3.region":                        "",
+		"storage.s3.prefix":                        "",
+		"storage.s3.endpoint":                      "",
+		"reporting.apogee_method":                  "",
+		"server.completion_webhook.url":            "",
+		"server.completion_webhook.retries":        "0",
 	}
 
 	actual := cfg.String()
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// lengthUnits converts length suffixes to metres
+var lengthUnits = map[string]float64{
+	"m":  1,
+	"km": 1000,
+	"cm": 0.01,
+	"mm": 0.001,
+	"ft": 0.3048,
+	"in": 0.0254,
+}
+
+// Length is a distance in metres that may be configured with a unit suffix such as "6ft"
+type Length float64
+
+// unitHook decodes strings such as "6ft" or "1200 ft" into Length fields, plain numbers are taken as SI.
+// Any other float field rejects a unit suffix rather than silently scaling it as a length.
+func unitHook(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Float64 {
+		return data, nil
+	}
+
+	s := data.(string)
+	if to == reflect.TypeOf(Length(0)) {
+		return parseLength(s)
+	}
+
+	if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+		return nil, fmt.Errorf("invalid number %q, unit suffixes are only accepted on lengths", s)
+	}
+	return data, nil
+}
+
+// parseLength parses a number with an optional length unit suffix into metres
+func parseLength(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	split := strings.LastIndexFunc(s, func(r rune) bool {
+		return (r >= '0' && r <= '9') || r == '.'
+	}) + 1
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(s[:split]), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+
+	unit := strings.TrimSpace(s[split:])
+	if unit == "" {
+		return value, nil
+	}
+
+	factor, ok := lengthUnits[strings.ToLower(unit)]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q in %q", unit, s)
+	}
+	return value * factor, nil
+}
+
+// decodeHook keeps viper's default hooks and adds unit suffixes
+func decodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		unitHook,
+	)
+}
@@ -3,22 +3,42 @@ package http_client
 import (
 	"bytes"
 	"net/http"
+	"time"
 )
 
+// DefaultTimeout bounds each request made by a client from NewHTTPClient
+const DefaultTimeout = 30 * time.Second
+
 // HTTPClient is an interface for making HTTP requests.
 type HTTPClient interface {
 	Post(url, contentType string, body *bytes.Buffer) (*http.Response, error)
+	Do(req *http.Request) (*http.Response, error)
 }
 
 // DefaultHTTPClient is the default implementation of HTTPClient.
-type DefaultHTTPClient struct{}
+type DefaultHTTPClient struct {
+	client *http.Client // Falls back to http.DefaultClient when nil
+}
 
 // Post makes an HTTP POST request.
 func (c *DefaultHTTPClient) Post(url, contentType string, body *bytes.Buffer) (*http.Response, error) {
-	return http.Post(url, contentType, body)
+	return c.httpClient().Post(url, contentType, body)
+}
+
+// Do sends an HTTP request, useful when custom headers are needed.
+func (c *DefaultHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	return c.httpClient().Do(req)
+}
+
+// httpClient returns the underlying client
+func (c *DefaultHTTPClient) httpClient() *http.Client {
+	if c.client == nil {
+		return http.DefaultClient
+	}
+	return c.client
 }
 
-// NewHTTPClient creates a new HTTPClient.
+// NewHTTPClient creates a new HTTPClient whose requests time out after DefaultTimeout.
 func NewHTTPClient() HTTPClient {
-	return &DefaultHTTPClient{}
+	return &DefaultHTTPClient{client: &http.Client{Timeout: DefaultTimeout}}
 }
@@ -17,3 +17,10 @@ func (m *MockHTTPClient) Post(url, contentType string, body *bytes.Buffer) (*htt
 	args := m.Called(url, contentType, body)
 	return args.Get(0).(*http.Response), args.Error(1)
 }
+
+// Do sends an HTTP request.
+func (m *MockHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	args := m.Called(req)
+	resp, _ := args.Get(0).(*http.Response)
+	return resp, args.Error(1)
+}
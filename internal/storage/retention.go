@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RetentionPolicy bounds the stored records, a zero limit is not enforced
+type RetentionPolicy struct {
+	MaxAge   time.Duration
+	MaxCount int
+	MaxBytes int64
+	DryRun   bool // Report what would be removed without deleting
+}
+
+// EnforceRetention removes the oldest records in baseDir/dir beyond the policy limits and returns their paths.
+// A record's sensor view is counted and removed along with it.
+func EnforceRetention(baseDir, dir string, policy RetentionPolicy) ([]string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(homeDir, baseDir, dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read records: %v", err)
+	}
+
+	type record struct {
+		path    string
+		sensor  string // Empty when the record has no sensor view
+		size    int64
+		modTime time.Time
+	}
+
+	records := make([]record, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasPrefix(name, "simulation_") || !strings.HasSuffix(name, ".csv") || strings.HasSuffix(name, sensorSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat record: %v", err)
+		}
+		r := record{
+			path:    filepath.Join(homeDir, baseDir, dir, name),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		}
+		if sensorInfo, err := os.Stat(SensorPath(r.path)); err == nil {
+			r.sensor = SensorPath(r.path)
+			r.size += sensorInfo.Size()
+		}
+		records = append(records, r)
+	}
+
+	// Newest first, so everything past a limit is the oldest
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].modTime.After(records[j].modTime)
+	})
+
+	var removed []string
+	var totalBytes int64
+	now := time.Now()
+	for i, r := range records {
+		totalBytes += r.size
+
+		expired := policy.MaxAge > 0 && now.Sub(r.modTime) > policy.MaxAge
+		overCount := policy.MaxCount > 0 && i >= policy.MaxCount
+		overBytes := policy.MaxBytes > 0 && totalBytes > policy.MaxBytes
+		if !expired && !overCount && !overBytes {
+			continue
+		}
+
+		for _, path := range []string{r.path, r.sensor} {
+			if path == "" {
+				continue
+			}
+			if !policy.DryRun {
+				if err := os.Remove(path); err != nil {
+					return removed, fmt.Errorf("failed to remove record: %v", err)
+				}
+			}
+			removed = append(removed, path)
+		}
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,147 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bxrne/launchrail/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRecords creates records of the given sizes, oldest first, and returns their paths
+func writeRecords(t *testing.T, baseDir, dir string, sizes ...int) []string {
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	fullDir := filepath.Join(homeDir, baseDir, dir)
+	require.NoError(t, os.MkdirAll(fullDir, 0755))
+
+	paths := make([]string, len(sizes))
+	for i, size := range sizes {
+		paths[i] = filepath.Join(fullDir, "simulation_"+string(rune('a'+i))+".csv")
+		require.NoError(t, os.WriteFile(paths[i], make([]byte, size), 0644))
+
+		modTime := time.Now().Add(-time.Duration(len(sizes)-i) * time.Hour)
+		require.NoError(t, os.Chtimes(paths[i], modTime, modTime))
+	}
+	return paths
+}
+
+// TEST: GIVEN records beyond each limit WHEN EnforceRetention is called THEN the oldest records are removed
+func TestEnforceRetention(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      storage.RetentionPolicy
+		wantRemoved []int
+	}{
+		{"No limits", storage.RetentionPolicy{}, nil},
+		{"Max count", storage.RetentionPolicy{MaxCount: 2}, []int{1, 0}},
+		{"Max age", storage.RetentionPolicy{MaxAge: 150 * time.Minute}, []int{1, 0}},
+		{"Max bytes", storage.RetentionPolicy{MaxBytes: 250}, []int{1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir, dir, cleanup := setupTest(t)
+			defer cleanup()
+
+			// Ages 4h, 3h, 2h and 1h
+			paths := writeRecords(t, baseDir, dir, 100, 100, 100, 100)
+
+			removed, err := storage.EnforceRetention(baseDir, dir, tt.policy)
+			require.NoError(t, err)
+
+			want := make([]string, 0, len(tt.wantRemoved))
+			for _, i := range tt.wantRemoved {
+				want = append(want, paths[i])
+			}
+			if len(tt.wantRemoved) == 0 {
+				assert.Empty(t, removed)
+			} else {
+				assert.Equal(t, want, removed)
+			}
+
+			for i, path := range paths {
+				_, err := os.Stat(path)
+				if contains(tt.wantRemoved, i) {
+					assert.True(t, os.IsNotExist(err), "%s should be removed", path)
+				} else {
+					assert.NoError(t, err, "%s should be kept", path)
+				}
+			}
+		})
+	}
+}
+
+// TEST: GIVEN a dry run policy WHEN EnforceRetention is called THEN records are reported but kept
+func TestEnforceRetentionDryRun(t *testing.T) {
+	baseDir, dir, cleanup := setupTest(t)
+	defer cleanup()
+
+	paths := writeRecords(t, baseDir, dir, 10, 10, 10)
+
+	removed, err := storage.EnforceRetention(baseDir, dir, storage.RetentionPolicy{MaxCount: 1, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{paths[1], paths[0]}, removed)
+
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		assert.NoError(t, err)
+	}
+}
+
+// TEST: GIVEN records with sensor views and unrelated files WHEN EnforceRetention is called THEN only whole records are counted and removed
+func TestEnforceRetentionSensorCompanions(t *testing.T) {
+	baseDir, dir, cleanup := setupTest(t)
+	defer cleanup()
+
+	paths := writeRecords(t, baseDir, dir, 100, 100, 100)
+	sensors := make([]string, len(paths))
+	for i, path := range paths {
+		sensors[i] = storage.SensorPath(path)
+		require.NoError(t, os.WriteFile(sensors[i], make([]byte, 100), 0644))
+	}
+	other := filepath.Join(filepath.Dir(paths[0]), "notes.csv")
+	require.NoError(t, os.WriteFile(other, nil, 0644))
+
+	// Two records with their sensor views fill the budget, the companions don't count as records
+	removed, err := storage.EnforceRetention(baseDir, dir, storage.RetentionPolicy{MaxCount: 2, MaxBytes: 400})
+	require.NoError(t, err)
+	assert.Equal(t, []string{paths[0], sensors[0]}, removed)
+
+	for i := range paths {
+		_, recordErr := os.Stat(paths[i])
+		_, sensorErr := os.Stat(sensors[i])
+		if i == 0 {
+			assert.True(t, os.IsNotExist(recordErr))
+			assert.True(t, os.IsNotExist(sensorErr))
+		} else {
+			assert.NoError(t, recordErr)
+			assert.NoError(t, sensorErr)
+		}
+	}
+	_, err = os.Stat(other)
+	assert.NoError(t, err)
+}
+
+// TEST: GIVEN no records directory WHEN EnforceRetention is called THEN nothing is removed
+func TestEnforceRetentionMissingDir(t *testing.T) {
+	baseDir, dir, cleanup := setupTest(t)
+	defer cleanup()
+
+	removed, err := storage.EnforceRetention(baseDir, dir, storage.RetentionPolicy{MaxCount: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+}
+
+func contains(indices []int, i int) bool {
+	for _, index := range indices {
+		if index == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Options locates the bucket an S3 store writes to, credentials come from the default AWS chain
+type S3Options struct {
+	Bucket   string
+	Region   string
+	Prefix   string // Key prefix, the store's directory is appended to it
+	Endpoint string // Overrides the AWS endpoint for S3 compatible services, using path style addressing
+}
+
+// S3Storage is a service that streams csv's to an S3 object
+// NOTE: Rows are piped into a multipart upload as they are written, so a record is never buffered whole.
+type S3Storage struct {
+	mu      sync.Mutex
+	bucket  string
+	key     string
+	headers []string
+	pipe    *io.PipeWriter
+	writer  *csv.Writer
+	hash    hash.Hash  // Hashes the bytes as they are written
+	done    chan error // Receives the upload result once the pipe is closed
+	closed  bool
+}
+
+// NewS3Storage creates a new storage service writing to a timestamped object under the prefix and dir
+func NewS3Storage(opts S3Options, dir string) (*S3Storage, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(opts.Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %v", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if opts.Endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	// Same object naming as the filesystem records
+	timestamp := time.Now().Format("20060102_150405")
+	key := path.Join(opts.Prefix, dir, fmt.Sprintf("simulation_%s.csv", timestamp))
+
+	reader, pipe := io.Pipe()
+	h := sha256.New()
+	s := &S3Storage{
+		bucket: opts.Bucket,
+		key:    key,
+		pipe:   pipe,
+		writer: csv.NewWriter(io.MultiWriter(pipe, h)),
+		hash:   h,
+		done:   make(chan error, 1),
+	}
+
+	go func() {
+		_, err := manager.NewUploader(client).Upload(context.Background(), &s3.PutObjectInput{
+			Bucket:      aws.String(opts.Bucket),
+			Key:         aws.String(key),
+			Body:        reader,
+			ContentType: aws.String("text/csv"),
+		})
+		// Unblocks any pending write if the upload gave up early
+		reader.CloseWithError(err)
+		s.done <- err
+	}()
+
+	return s, nil
+}
+
+// Init initializes the storage service with headers
+func (s *S3Storage) Init(headers []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+
+	s.headers = headers
+	if err := s.writer.Write(headers); err != nil {
+		return fmt.Errorf("failed to write headers: %v", err)
+	}
+	s.writer.Flush()
+	if err := s.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write headers: %v", err)
+	}
+	return nil
+}
+
+// Write writes a record to the storage service
+func (s *S3Storage) Write(data []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	if len(data) != len(s.headers) {
+		return fmt.Errorf("data length (%d) does not match headers length (%d)", len(data), len(s.headers))
+	}
+
+	if err := s.writer.Write(data); err != nil {
+		return fmt.Errorf("failed to write data: %v", err)
+	}
+	s.writer.Flush()
+
+	if err := s.writer.Error(); err != nil {
+		return fmt.Errorf("failed to upload data: %v", err)
+	}
+
+	return nil
+}
+
+// Close ends the stream and waits for the upload to complete, later calls do nothing
+func (s *S3Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	s.writer.Flush()
+	flushErr := s.writer.Error()
+	s.pipe.Close()
+
+	if err := <-s.done; err != nil {
+		return fmt.Errorf("failed to upload record: %v", err)
+	}
+	if flushErr != nil {
+		return fmt.Errorf("failed to flush on close: %v", flushErr)
+	}
+	return nil
+}
+
+// GetFilePath returns the s3:// URI of the record
+func (s *S3Storage) GetFilePath() string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, s.key)
+}
+
+// Hash returns the hex SHA-256 of the record written so far
+func (s *S3Storage) Hash() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return hex.EncodeToString(s.hash.Sum(nil))
+}
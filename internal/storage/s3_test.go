@@ -0,0 +1,113 @@
+package storage_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/bxrne/launchrail/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 records the objects PUT to it by path
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	f.objects[r.URL.Path] = body
+	f.mu.Unlock()
+	w.Header().Set("ETag", `"etag"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeS3) object(path string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, ok := f.objects[path]
+	return body, ok
+}
+
+// setupS3 points the default AWS chain at static test credentials and a fake S3 server
+func setupS3(t *testing.T) (*fakeS3, storage.S3Options) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_CONFIG_FILE", os.DevNull)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", os.DevNull)
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return fake, storage.S3Options{Bucket: "records", Region: "eu-west-1", Prefix: "launchrail", Endpoint: server.URL}
+}
+
+// TEST: GIVEN the s3 backend WHEN rows are written and the store closed THEN the object matches the filesystem record byte for byte
+func TestS3Storage(t *testing.T) {
+	fake, opts := setupS3(t)
+	baseDir, dir, cleanup := setupTest(t)
+	defer cleanup()
+
+	headers := []string{"time", "altitude"}
+	rows := [][]string{{"0.001", "0"}, {"0.002", "1.5e-05"}, {"0.003", "quoted, value"}}
+
+	stores := make([]storage.Store, 0, 2)
+	for _, backend := range []string{storage.BackendFS, storage.BackendS3} {
+		s, err := storage.New(backend, baseDir, dir, opts)
+		require.NoError(t, err)
+		require.NoError(t, s.Init(headers))
+		for _, row := range rows {
+			require.NoError(t, s.Write(row))
+		}
+		require.NoError(t, s.Close())
+		stores = append(stores, s)
+	}
+
+	want, err := os.ReadFile(stores[0].GetFilePath())
+	require.NoError(t, err)
+
+	s3Store := stores[1]
+	assert.Regexp(t, `^s3://records/launchrail/test_dir/simulation_\d{8}_\d{6}\.csv$`, s3Store.GetFilePath())
+	got, ok := fake.object("/records" + s3Store.GetFilePath()[len("s3://records"):])
+	require.True(t, ok, "object was not uploaded")
+	assert.Equal(t, string(want), string(got))
+	assert.Equal(t, stores[0].Hash(), s3Store.Hash())
+
+	assert.NoError(t, s3Store.Close(), "Close is idempotent")
+	assert.ErrorIs(t, s3Store.Write(rows[0]), storage.ErrClosed)
+}
+
+// TEST: GIVEN the s3 backend WHEN a row doesn't match the headers THEN an error is returned
+func TestS3StorageWriteInvalidData(t *testing.T) {
+	_, opts := setupS3(t)
+
+	s, err := storage.NewS3Storage(opts, "motion")
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Init([]string{"Column1", "Column2"}))
+	assert.EqualError(t, s.Write([]string{"Value1", "Value2", "Value3"}), "data length (3) does not match headers length (2)")
+}
+
+// TEST: GIVEN no bucket WHEN NewS3Storage is called THEN an error is returned
+func TestNewS3StorageRequiresBucket(t *testing.T) {
+	_, err := storage.NewS3Storage(storage.S3Options{Region: "eu-west-1"}, "motion")
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// sensorSuffix replaces a record's .csv extension to name its sensor view
+const sensorSuffix = "_sensor.csv"
+
+// SensorPath returns the path of the sensor view written alongside a record
+func SensorPath(recordPath string) string {
+	return strings.TrimSuffix(recordPath, ".csv") + sensorSuffix
+}
+
+// SensorNoise describes how a stored column is degraded to resemble a real sensor
+type SensorNoise struct {
+	Column  string  // Header of the column to degrade
+	StdDev  float64 // Gaussian noise standard deviation, in the column's units
+	Quantum float64 // Quantization step, 0 to disable
+}
+
+// EmulateSensor writes a noisy "sensor view" copy of a stored CSV next to it and returns its path
+// NOTE: The source file is only read, the clean physics data is left untouched.
+func EmulateSensor(srcPath string, seed int64, noise []SensorNoise) (string, error) {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open source: %v", err)
+	}
+	defer src.Close()
+
+	records, err := csv.NewReader(src).ReadAll()
+	if err != nil {
+		return "", fmt.Errorf("failed to read source: %v", err)
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("source has no headers")
+	}
+
+	// Resolve the columns to degrade, kept in order so the seeded draws are reproducible
+	columns := make([]int, len(noise))
+	for c, n := range noise {
+		index := -1
+		for i, header := range records[0] {
+			if header == n.Column {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return "", fmt.Errorf("column %s not found", n.Column)
+		}
+		columns[c] = index
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for _, record := range records[1:] {
+		for c, i := range columns {
+			n := noise[c]
+			value, err := strconv.ParseFloat(record[i], 64)
+			if err != nil {
+				return "", fmt.Errorf("failed to parse %s value %q: %v", n.Column, record[i], err)
+			}
+
+			value += rng.NormFloat64() * n.StdDev
+			if n.Quantum > 0 {
+				value = math.Round(value/n.Quantum) * n.Quantum
+			}
+			record[i] = strconv.FormatFloat(value, 'f', -1, 64)
+		}
+	}
+
+	dstPath := SensorPath(srcPath)
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %v", err)
+	}
+	defer dst.Close()
+
+	writer := csv.NewWriter(dst)
+	if err := writer.WriteAll(records); err != nil {
+		return "", fmt.Errorf("failed to write data: %v", err)
+	}
+
+	return dstPath, nil
+}
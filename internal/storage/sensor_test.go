@@ -0,0 +1,95 @@
+package storage_test
+
+import (
+	"encoding/csv"
+	"math"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/bxrne/launchrail/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCleanStore(t *testing.T) string {
+	baseDir, dir, cleanup := setupTest(t)
+	t.Cleanup(cleanup)
+
+	s, err := storage.NewStorage(baseDir, dir)
+	require.NoError(t, err)
+	require.NoError(t, s.Init([]string{"time", "altitude", "velocity"}))
+	for i := 0; i < 100; i++ {
+		require.NoError(t, s.Write([]string{
+			strconv.Itoa(i),
+			strconv.FormatFloat(float64(i)*10, 'f', 6, 64),
+			strconv.FormatFloat(50, 'f', 6, 64),
+		}))
+	}
+	require.NoError(t, s.Close())
+
+	return s.GetFilePath()
+}
+
+func readStore(t *testing.T, path string) [][]string {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+// TEST: GIVEN a clean store WHEN EmulateSensor is called THEN a noisy quantized copy is written and the source is unchanged
+func TestEmulateSensor(t *testing.T) {
+	src := writeCleanStore(t)
+	clean := readStore(t, src)
+
+	noise := []storage.SensorNoise{
+		{Column: "altitude", StdDev: 2, Quantum: 0.5},
+		{Column: "velocity", StdDev: 0.5},
+	}
+	dst, err := storage.EmulateSensor(src, 42, noise)
+	require.NoError(t, err)
+	assert.NotEqual(t, src, dst)
+
+	sensor := readStore(t, dst)
+	require.Len(t, sensor, len(clean))
+	assert.Equal(t, clean, readStore(t, src), "Clean store should be untouched")
+	assert.Equal(t, clean[0], sensor[0])
+
+	var sumSq float64
+	for i := 1; i < len(sensor); i++ {
+		assert.Equal(t, clean[i][0], sensor[i][0], "Unlisted columns should be copied as-is")
+
+		altitude, err := strconv.ParseFloat(sensor[i][1], 64)
+		require.NoError(t, err)
+		assert.Zero(t, math.Mod(altitude, 0.5), "Altitude should be quantized")
+
+		cleanAltitude, _ := strconv.ParseFloat(clean[i][1], 64)
+		sumSq += (altitude - cleanAltitude) * (altitude - cleanAltitude)
+	}
+	assert.InDelta(t, 2, math.Sqrt(sumSq/float64(len(sensor)-1)), 0.5)
+}
+
+// TEST: GIVEN the same seed WHEN EmulateSensor is called twice THEN the output is identical
+func TestEmulateSensorSeeded(t *testing.T) {
+	src := writeCleanStore(t)
+	noise := []storage.SensorNoise{{Column: "altitude", StdDev: 1}}
+
+	first, err := storage.EmulateSensor(src, 7, noise)
+	require.NoError(t, err)
+	firstData := readStore(t, first)
+
+	second, err := storage.EmulateSensor(src, 7, noise)
+	require.NoError(t, err)
+	assert.Equal(t, firstData, readStore(t, second))
+}
+
+// TEST: GIVEN an unknown column WHEN EmulateSensor is called THEN an error is returned
+func TestEmulateSensorUnknownColumn(t *testing.T) {
+	src := writeCleanStore(t)
+	_, err := storage.EmulateSensor(src, 1, []storage.SensorNoise{{Column: "pressure", StdDev: 1}})
+	assert.EqualError(t, err, "column pressure not found")
+}
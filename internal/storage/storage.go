@@ -1,14 +1,52 @@
 package storage
 
 import (
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 )
 
+// ErrUnsupportedBackend is returned when a storage backend is not available
+var ErrUnsupportedBackend = errors.New("unsupported storage backend")
+
+// ErrClosed is returned when writing to a store after Close
+var ErrClosed = errors.New("storage is closed")
+
+// Backend constants
+const (
+	BackendFS = "fs"
+	BackendS3 = "s3"
+)
+
+// Store is a service that records csv rows
+type Store interface {
+	Init(headers []string) error
+	Write(data []string) error
+	Close() error
+	GetFilePath() string
+	Hash() string
+}
+
+// New creates a store for the given backend, defaulting to the filesystem
+func New(backend, baseDir, dir string, s3 S3Options) (Store, error) {
+	switch backend {
+	case "", BackendFS:
+		return NewStorage(baseDir, dir)
+	case BackendS3:
+		return NewS3Storage(s3, dir)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBackend, backend)
+	}
+}
+
 // Storage is a service that writes csv's to disk
 type Storage struct {
 	baseDir  string
@@ -18,6 +56,7 @@ type Storage struct {
 	filePath string
 	writer   *csv.Writer
 	file     *os.File
+	hash     hash.Hash // Hashes the bytes as they are written
 }
 
 // NewStorage creates a new storage service
@@ -33,7 +72,11 @@ func NewStorage(baseDir, dir string) (*Storage, error) {
 		return nil, err
 	}
 
-	dir = filepath.Join(baseDir, dir)
+	return NewStorageIn(filepath.Join(baseDir, dir))
+}
+
+// NewStorageIn creates a new storage service writing into dir as given, without resolving it against the home directory
+func NewStorageIn(dir string) (*Storage, error) {
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, err
 	}
@@ -47,12 +90,14 @@ func NewStorage(baseDir, dir string) (*Storage, error) {
 		return nil, fmt.Errorf("failed to create file: %v", err)
 	}
 
+	h := sha256.New()
 	return &Storage{
-		baseDir:  baseDir,
+		baseDir:  filepath.Dir(dir),
 		dir:      dir,
 		filePath: filePath,
 		file:     file,
-		writer:   csv.NewWriter(file),
+		writer:   csv.NewWriter(io.MultiWriter(file, h)),
+		hash:     h,
 	}, nil
 }
 
@@ -61,6 +106,10 @@ func (s *Storage) Init(headers []string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.writer == nil {
+		return ErrClosed
+	}
+
 	s.headers = headers
 	if err := s.writer.Write(headers); err != nil {
 		return fmt.Errorf("failed to write headers: %v", err)
@@ -74,6 +123,9 @@ func (s *Storage) Write(data []string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.writer == nil {
+		return ErrClosed
+	}
 	if len(data) != len(s.headers) {
 		return fmt.Errorf("data length (%d) does not match headers length (%d)", len(data), len(s.headers))
 	}
@@ -92,28 +144,37 @@ func (s *Storage) Write(data []string) error {
 	return nil
 }
 
-// Close closes the storage service
+// Close closes the storage service, later calls do nothing
 func (s *Storage) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if s.writer != nil {
-		s.writer.Flush()
-		if err := s.writer.Error(); err != nil {
-			return fmt.Errorf("failed to flush on close: %v", err)
-		}
+	if s.file == nil {
+		return nil
 	}
+	file, writer := s.file, s.writer
+	s.file, s.writer = nil, nil
 
-	if s.file != nil {
-		if err := s.file.Sync(); err != nil {
-			return fmt.Errorf("failed to sync file: %v", err)
-		}
-		return s.file.Close()
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to flush on close: %v", err)
 	}
-	return nil
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync file: %v", err)
+	}
+	return file.Close()
 }
 
 // GetFilePath returns the file path of the storage service
 func (s *Storage) GetFilePath() string {
 	return s.filePath
 }
+
+// Hash returns the hex SHA-256 of the record written so far
+func (s *Storage) Hash() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return hex.EncodeToString(s.hash.Sum(nil))
+}
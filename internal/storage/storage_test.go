@@ -1,7 +1,9 @@
 package storage_test
 
 import (
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/hex"
 	"os"
 	"path/filepath"
 	"testing"
@@ -44,6 +46,19 @@ func TestNewStorage(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TEST: GIVEN an absolute directory WHEN NewStorageIn is called THEN the record is created inside it
+func TestNewStorageIn(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "motion")
+
+	s, err := storage.NewStorageIn(dir)
+	require.NoError(t, err)
+	defer s.Close()
+
+	assert.Equal(t, dir, filepath.Dir(s.GetFilePath()))
+	_, err = os.Stat(s.GetFilePath())
+	assert.NoError(t, err)
+}
+
 // TEST: GIVEN a base directory and a directory name WHEN NewStorage is called THEN a new storage instance is created
 func TestInit(t *testing.T) {
 	baseDir, dir, cleanup := setupTest(t)
@@ -128,3 +143,44 @@ func TestWriteInvalidData(t *testing.T) {
 	require.Error(t, err)
 	assert.EqualError(t, err, "data length (3) does not match headers length (2)")
 }
+
+// TEST: GIVEN a written record WHEN Hash is called THEN the SHA-256 of the file is returned
+func TestHash(t *testing.T) {
+	s, err := storage.NewStorageIn(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, s.Init([]string{"time", "altitude"}))
+	require.NoError(t, s.Write([]string{"0.001", "1.5"}))
+	require.NoError(t, s.Close())
+
+	data, err := os.ReadFile(s.GetFilePath())
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), s.Hash())
+}
+
+// TEST: GIVEN a closed store WHEN Close is called again THEN it succeeds and later writes are rejected
+func TestCloseIdempotent(t *testing.T) {
+	s, err := storage.NewStorageIn(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, s.Init([]string{"time"}))
+
+	require.NoError(t, s.Close())
+	assert.NoError(t, s.Close())
+	assert.ErrorIs(t, s.Write([]string{"0.001"}), storage.ErrClosed)
+}
+
+// TEST: GIVEN a backend name WHEN New is called THEN the filesystem store is returned or the backend is rejected
+func TestNewBackend(t *testing.T) {
+	baseDir, dir, cleanup := setupTest(t)
+	defer cleanup()
+
+	for _, backend := range []string{"", storage.BackendFS} {
+		s, err := storage.New(backend, baseDir, dir, storage.S3Options{})
+		require.NoError(t, err)
+		assert.IsType(t, &storage.Storage{}, s)
+		require.NoError(t, s.Close())
+	}
+
+	_, err := storage.New("ftp", baseDir, dir, storage.S3Options{})
+	assert.ErrorIs(t, err, storage.ErrUnsupportedBackend)
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bxrne/launchrail/internal/http_client"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body when a secret is configured
+const SignatureHeader = "X-Launchrail-Signature"
+
+// retryDelay is the base delay between attempts, doubled after each failure
+var retryDelay = 250 * time.Millisecond
+
+// Payload is the body posted to the completion webhook
+type Payload struct {
+	Record       string  `json:"record,omitempty"`      // Empty when the motion store is disabled
+	RecordHash   string  `json:"record_hash,omitempty"` // Hex SHA-256 of the record
+	Apogee       float64 `json:"apogee"`
+	TimeToApogee float64 `json:"time_to_apogee"`
+	MaxVelocity  float64 `json:"max_velocity"`
+	MaxAccel     float64 `json:"max_acceleration"`
+	MaxMach      float64 `json:"max_mach"`
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body using the secret
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notify posts the payload to the URL, retrying failed attempts up to retries times.
+// Delivery gives up once ctx is done, including while waiting to retry.
+func Notify(ctx context.Context, client http_client.HTTPClient, url, secret string, payload Payload, retries int) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %v", err)
+	}
+
+	delay := retryDelay
+	for attempt := 0; ; attempt++ {
+		err = send(ctx, client, url, secret, body)
+		if err == nil || attempt >= retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("webhook gave up after %d attempts: %v", attempt+1, err)
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// send makes a single signed webhook request
+func send(ctx context.Context, client http_client.HTTPClient, url, secret string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(body, secret))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %v", err)
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bxrne/launchrail/internal/http_client"
+	"github.com/bxrne/launchrail/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TEST: GIVEN a body and secret WHEN Sign is called THEN the HMAC-SHA256 hex digest is returned
+func TestSign(t *testing.T) {
+	// Reference value from RFC 4231 test case 2
+	assert.Equal(t,
+		"5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		webhook.Sign([]byte("what do ya want for nothing?"), "Jefe"))
+}
+
+// TEST: GIVEN a webhook that fails once WHEN Notify is called with a retry THEN the signed payload is delivered
+func TestNotify_RetriesAndSigns(t *testing.T) {
+	var mu sync.Mutex
+	var calls int
+	var body []byte
+	var signature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(webhook.SignatureHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	payload := webhook.Payload{Record: "simulation_20240101_000000.csv", RecordHash: "9f86d081", Apogee: 512.3}
+	err := webhook.Notify(context.Background(), http_client.NewHTTPClient(), server.URL, "secret", payload, 1)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "sha256="+webhook.Sign(body, "secret"), signature)
+
+	var received webhook.Payload
+	require.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, payload, received)
+}
+
+// TEST: GIVEN a failing webhook WHEN Notify is called without retries THEN an error is returned
+func TestNotify_Failure(t *testing.T) {
+	var mu sync.Mutex
+	var signature string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		signature = r.Header.Get(webhook.SignatureHeader)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := webhook.Notify(context.Background(), http_client.NewHTTPClient(), server.URL, "", webhook.Payload{}, 0)
+	assert.Error(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Empty(t, signature)
+}
+
+// TEST: GIVEN a webhook that never answers WHEN Notify is called with a deadline THEN it returns once the deadline passes
+func TestNotify_Deadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := webhook.Notify(ctx, http_client.NewHTTPClient(), server.URL, "", webhook.Payload{}, 5)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,120 @@
+package aerodeck
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Point is one row of an aero deck
+type Point struct {
+	Mach    float64
+	Cd      float64
+	CP      float64 // Centre of pressure from the nose tip in m
+	CNAlpha float64 // Normal force coefficient slope per radian
+}
+
+// Deck is an aerodynamic table indexed by Mach
+type Deck struct {
+	Points []Point
+}
+
+// Load reads a RASAero style CSV deck with Mach, Cd, CP and CNα columns
+func Load(path string) (*Deck, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Parse reads a deck from CSV, a non-numeric first row is treated as a header
+func Parse(r io.Reader) (*Deck, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aero deck: %s", err)
+	}
+
+	deck := &Deck{}
+	for i, record := range records {
+		if len(record) < 4 {
+			return nil, fmt.Errorf("aero deck row %d: expected 4 columns, got %d", i+1, len(record))
+		}
+
+		values := make([]float64, 4)
+		for j := range values {
+			values[j], err = strconv.ParseFloat(strings.TrimSpace(record[j]), 64)
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			if i == 0 {
+				continue // Header
+			}
+			return nil, fmt.Errorf("aero deck row %d: %s", i+1, err)
+		}
+
+		deck.Points = append(deck.Points, Point{Mach: values[0], Cd: values[1], CP: values[2], CNAlpha: values[3]})
+	}
+
+	if len(deck.Points) == 0 {
+		return nil, fmt.Errorf("aero deck has no data rows")
+	}
+
+	sort.Slice(deck.Points, func(i, j int) bool {
+		return deck.Points[i].Mach < deck.Points[j].Mach
+	})
+	for i := 1; i < len(deck.Points); i++ {
+		if deck.Points[i].Mach == deck.Points[i-1].Mach {
+			return nil, fmt.Errorf("aero deck has duplicate Mach %.3f", deck.Points[i].Mach)
+		}
+	}
+
+	return deck, nil
+}
+
+// MachRange returns the lowest and highest Mach covered by the deck
+func (d *Deck) MachRange() (float64, float64) {
+	return d.Points[0].Mach, d.Points[len(d.Points)-1].Mach
+}
+
+// Covers reports whether a Mach number lies within the deck
+func (d *Deck) Covers(mach float64) bool {
+	lo, hi := d.MachRange()
+	return mach >= lo && mach <= hi
+}
+
+// Lookup linearly interpolates the deck at a Mach number, holding the end rows outside its range
+func (d *Deck) Lookup(mach float64) Point {
+	if mach <= d.Points[0].Mach {
+		return d.Points[0]
+	}
+	last := d.Points[len(d.Points)-1]
+	if mach >= last.Mach {
+		return last
+	}
+
+	i := sort.Search(len(d.Points), func(i int) bool {
+		return d.Points[i].Mach >= mach
+	})
+	p0, p1 := d.Points[i-1], d.Points[i]
+	f := (mach - p0.Mach) / (p1.Mach - p0.Mach)
+
+	return Point{
+		Mach:    mach,
+		Cd:      p0.Cd + f*(p1.Cd-p0.Cd),
+		CP:      p0.CP + f*(p1.CP-p0.CP),
+		CNAlpha: p0.CNAlpha + f*(p1.CNAlpha-p0.CNAlpha),
+	}
+}
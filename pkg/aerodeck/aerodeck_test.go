@@ -0,0 +1,49 @@
+package aerodeck_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bxrne/launchrail/pkg/aerodeck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TEST: GIVEN a deck file WHEN Load is called THEN the rows are parsed in Mach order
+func TestLoad(t *testing.T) {
+	deck, err := aerodeck.Load("../../testdata/aerodeck/l1.csv")
+	require.NoError(t, err)
+	assert.Len(t, deck.Points, 7)
+
+	lo, hi := deck.MachRange()
+	assert.Equal(t, 0.0, lo)
+	assert.Equal(t, 2.0, hi)
+}
+
+// TEST: GIVEN a deck WHEN Lookup is called between rows THEN values are interpolated by Mach
+func TestLookup(t *testing.T) {
+	deck, err := aerodeck.Parse(strings.NewReader("1.0,0.6,1.0,10\n0.5,0.4,0.8,8\n"))
+	require.NoError(t, err)
+
+	p := deck.Lookup(0.75)
+	assert.InDelta(t, 0.5, p.Cd, 1e-9)
+	assert.InDelta(t, 0.9, p.CP, 1e-9)
+	assert.InDelta(t, 9.0, p.CNAlpha, 1e-9)
+
+	assert.False(t, deck.Covers(1.2))
+	assert.Equal(t, 0.6, deck.Lookup(1.2).Cd, "Extrapolation holds the last row")
+	assert.Equal(t, 0.4, deck.Lookup(0.1).Cd)
+}
+
+// TEST: GIVEN malformed decks WHEN Parse is called THEN an error is returned
+func TestParse_Invalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"empty":     "Mach,Cd,CP,CNalpha\n",
+		"short row": "0.1,0.4,0.8\n",
+		"bad value": "0.1,0.4,0.8,8\n0.2,x,0.8,8\n",
+		"duplicate": "0.1,0.4,0.8,8\n0.1,0.5,0.8,8\n",
+	} {
+		_, err := aerodeck.Parse(strings.NewReader(data))
+		assert.Error(t, err, name)
+	}
+}
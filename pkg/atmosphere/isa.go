@@ -12,6 +12,7 @@ type ISAModel struct {
 	cache map[float64]AtmosphereData
 	cfg   *config.ISAConfiguration
 	mu    sync.RWMutex
+	table []AtmosphereData // Precomputed at tableStep intervals from sea level, nil computes directly
 }
 
 // AtmosphereData contains atmospheric properties at a given altitude
@@ -31,11 +32,15 @@ func NewISAModel(cfg *config.ISAConfiguration) *ISAModel {
 
 // GetTemperature calculates the temperature at a given altitude
 func (isa *ISAModel) GetTemperature(altitude float64) float64 {
-	return isa.cfg.SeaLevelTemperature + isa.cfg.TemperatureLapseRate*altitude
+	return isa.cfg.SeaLevelTemperature + isa.cfg.TemperatureGradient()*altitude
 }
 
 // GetAtmosphere returns atmospheric data for a given altitude using memoization
 func (isa *ISAModel) GetAtmosphere(altitude float64) AtmosphereData {
+	if data, ok := isa.lookup(altitude); ok {
+		return data
+	}
+
 	// Round altitude to nearest meter for caching
 	roundedAlt := math.Round(altitude)
 
@@ -46,16 +51,7 @@ func (isa *ISAModel) GetAtmosphere(altitude float64) AtmosphereData {
 	}
 	isa.mu.RUnlock()
 
-	// Calculate new values
-	temp := isa.cfg.SeaLevelTemperature + isa.cfg.TemperatureLapseRate*altitude // T_0 (sea level temperature) - Lapse rate * altitude
-	pressure := isa.cfg.SeaLevelPressure * math.Pow(temp/isa.cfg.SeaLevelTemperature, -isa.cfg.GravitationalAccel/(isa.cfg.TemperatureLapseRate*isa.cfg.SpecificGasConstant))
-	density := pressure / (isa.cfg.SpecificGasConstant * temp)
-
-	data := AtmosphereData{
-		Density:     density,
-		Temperature: temp,
-		Pressure:    pressure,
-	}
+	data := isa.compute(altitude)
 
 	// Cache the result
 	isa.mu.Lock()
@@ -65,8 +61,31 @@ func (isa *ISAModel) GetAtmosphere(altitude float64) AtmosphereData {
 	return data
 }
 
+// compute evaluates the ISA formulas at an altitude
+func (isa *ISAModel) compute(altitude float64) AtmosphereData {
+	gradient := isa.cfg.TemperatureGradient()
+	temp := isa.cfg.SeaLevelTemperature + gradient*altitude // T_0 (sea level temperature) - lapse rate * altitude
+	pressure := isa.cfg.SeaLevelPressure * math.Pow(temp/isa.cfg.SeaLevelTemperature, -isa.cfg.GravitationalAccel/(gradient*isa.cfg.SpecificGasConstant))
+	density := pressure / (isa.cfg.SpecificGasConstant * temp)
+
+	return AtmosphereData{
+		Density:     density,
+		Temperature: temp,
+		Pressure:    pressure,
+	}
+}
+
 // GetSpeedOfSound calculates speed of sound at given altitude
 func (isa *ISAModel) GetSpeedOfSound(altitude float64) float64 {
 	atm := isa.GetAtmosphere(altitude)
 	return math.Sqrt(isa.cfg.RatioSpecificHeats * isa.cfg.SpecificGasConstant * atm.Temperature)
 }
+
+// GetMach returns the Mach number for a speed at altitude using the local speed of sound
+func (isa *ISAModel) GetMach(velocity, altitude float64) float64 {
+	soundSpeed := isa.GetSpeedOfSound(altitude)
+	if soundSpeed <= 0 || math.IsNaN(soundSpeed) {
+		return 0
+	}
+	return math.Abs(velocity) / soundSpeed
+}
@@ -52,6 +52,20 @@ func TestISAModel_GetTemperature(t *testing.T) {
 	}
 }
 
+// TEST: GIVEN a positive or a deprecated negative lapse rate WHEN the atmosphere is computed THEN both cool with altitude identically
+func TestISAModel_LapseRateSign(t *testing.T) {
+	negative := getTestConfig()
+	positive := getTestConfig()
+	positive.TemperatureLapseRate = 0.0065
+
+	for _, altitude := range []float64{0, 1000, 5000} {
+		want := atmosphere.NewISAModel(negative).GetAtmosphere(altitude)
+		got := atmosphere.NewISAModel(positive).GetAtmosphere(altitude)
+		assert.Equal(t, want, got, "altitude %v", altitude)
+		assert.InDelta(t, 288.15-0.0065*altitude, got.Temperature, 1e-9)
+	}
+}
+
 // TEST: GIVEN an ISAModel WHEN GetAtmosphere is called THEN correct atmospheric data is returned
 func TestISAModel_GetAtmosphere(t *testing.T) {
 	isa := atmosphere.NewISAModel(getTestConfig())
@@ -135,3 +149,25 @@ func TestISAModel_ConcurrentAccess(t *testing.T) {
 		<-done
 	}
 }
+
+// TEST: GIVEN a fixed velocity WHEN GetMach is called at increasing altitudes THEN Mach increases as the air cools
+func TestISAModel_GetMach(t *testing.T) {
+	isa := atmosphere.NewISAModel(getTestConfig())
+
+	assert.InDelta(t, 1.0, isa.GetMach(340.29, 0), 0.001)
+	assert.InDelta(t, 1.0, isa.GetMach(-340.29, 0), 0.001, "Mach is a speed ratio")
+
+	previous := 0.0
+	for _, altitude := range []float64{0, 1000, 5000, 11000} {
+		mach := isa.GetMach(300, altitude)
+		assert.Greater(t, mach, previous, "altitude %v", altitude)
+		previous = mach
+	}
+	assert.InDelta(t, 300/295.07, previous, 0.001)
+}
+
+// TEST: GIVEN an unconfigured ISAModel WHEN GetMach is called THEN zero is returned instead of NaN
+func TestISAModel_GetMachUnconfigured(t *testing.T) {
+	isa := atmosphere.NewISAModel(&config.ISAConfiguration{})
+	assert.Equal(t, 0.0, isa.GetMach(100, 0))
+}
@@ -0,0 +1,44 @@
+package atmosphere
+
+import "github.com/bxrne/launchrail/internal/config"
+
+// Precomputed table resolution and extent, altitudes outside it are computed directly
+const (
+	tableStep        = 10.0    // m
+	tableMaxAltitude = 30000.0 // m
+)
+
+// NewModel creates an ISAModel for the atmosphere config, precomputing its table when enabled
+func NewModel(cfg *config.Atmosphere) *ISAModel {
+	isa := NewISAModel(&cfg.ISAConfiguration)
+	if cfg.Precompute {
+		isa.Precompute()
+	}
+	return isa
+}
+
+// Precompute tabulates the atmosphere from sea level so later lookups interpolate instead of computing
+func (isa *ISAModel) Precompute() {
+	table := make([]AtmosphereData, int(tableMaxAltitude/tableStep)+1)
+	for i := range table {
+		table[i] = isa.compute(float64(i) * tableStep)
+	}
+	isa.table = table
+}
+
+// lookup linearly interpolates the precomputed table, reporting false outside it or when not precomputed
+func (isa *ISAModel) lookup(altitude float64) (AtmosphereData, bool) {
+	if isa.table == nil || altitude < 0 || altitude >= tableMaxAltitude {
+		return AtmosphereData{}, false
+	}
+
+	i := int(altitude / tableStep)
+	f := altitude/tableStep - float64(i)
+	lo, hi := isa.table[i], isa.table[i+1]
+
+	return AtmosphereData{
+		Density:     lo.Density + f*(hi.Density-lo.Density),
+		Temperature: lo.Temperature + f*(hi.Temperature-lo.Temperature),
+		Pressure:    lo.Pressure + f*(hi.Pressure-lo.Pressure),
+	}, true
+}
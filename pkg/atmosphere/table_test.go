@@ -0,0 +1,39 @@
+package atmosphere_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bxrne/launchrail/internal/config"
+	"github.com/bxrne/launchrail/pkg/atmosphere"
+	"github.com/stretchr/testify/assert"
+)
+
+// TEST: GIVEN a precomputed ISAModel WHEN GetAtmosphere is called between table rows THEN the interpolation error is bounded
+func TestISAModel_PrecomputeError(t *testing.T) {
+	direct := atmosphere.NewISAModel(getTestConfig())
+	table := atmosphere.NewModel(&config.Atmosphere{ISAConfiguration: *getTestConfig(), Precompute: true})
+
+	for altitude := 0.37; altitude < 30000; altitude += 97.3 {
+		want := direct.GetAtmosphere(altitude)
+		got := table.GetAtmosphere(altitude)
+		assert.InDelta(t, want.Temperature, got.Temperature, 1e-9, "temperature at %.2fm", altitude)
+		assert.LessOrEqual(t, math.Abs(got.Density-want.Density)/want.Density, 1e-5, "density at %.2fm", altitude)
+		assert.LessOrEqual(t, math.Abs(got.Pressure-want.Pressure)/want.Pressure, 1e-5, "pressure at %.2fm", altitude)
+	}
+
+	// Outside the table falls back to direct computation
+	assert.Equal(t, direct.GetAtmosphere(35000), table.GetAtmosphere(35000))
+}
+
+func BenchmarkISAModel_GetAtmosphere(b *testing.B) {
+	for name, precompute := range map[string]bool{"direct": false, "precomputed": true} {
+		b.Run(name, func(b *testing.B) {
+			isa := atmosphere.NewModel(&config.Atmosphere{ISAConfiguration: *getTestConfig(), Precompute: precompute})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				isa.GetAtmosphere(float64(i%3000000) / 100)
+			}
+		})
+	}
+}
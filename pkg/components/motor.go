@@ -39,6 +39,12 @@ type Motor struct {
 	isCoasting  bool
 	logger      logf.Logger
 	state       MotorState
+	// Propellant is consumed in proportion to the impulse delivered
+	propellantMass float64
+	totalImpulse   float64
+	impulse        float64
+	// Impulse delivered by the last Update divided by its timestep
+	averageThrust float64
 }
 
 // NewMotor creates a new motor component from thrust curve data
@@ -47,10 +53,16 @@ func NewMotor(id ecs.BasicEntity, md *thrustcurves.MotorData, logger logf.Logger
 		panic("invalid motor data")
 	}
 
+	// Noise near cutoff can dip below zero, which would push the rocket backwards
+	curve, clamped := thrustcurves.ClampNegativeThrust(md.Thrust)
+	if clamped > 0 {
+		logger.Warn("Clamped negative thrust points to zero", "count", clamped)
+	}
+
 	m := &Motor{
 		ID:          id,
 		Position:    types.Vector3{},
-		Thrustcurve: validateThrustCurve(md.Thrust),
+		Thrustcurve: validateThrustCurve(curve),
 		Mass:        md.TotalMass,
 		Props:       md,
 		thrust:      0,
@@ -61,6 +73,13 @@ func NewMotor(id ecs.BasicEntity, md *thrustcurves.MotorData, logger logf.Logger
 		state:       MotorIgnited, // Initial state
 	}
 
+	// Without a known propellant mass assume the whole motor mass is expended
+	m.propellantMass = md.WetMass
+	if m.propellantMass <= 0 || m.propellantMass > md.TotalMass {
+		m.propellantMass = md.TotalMass
+	}
+	m.totalImpulse = integrateThrustCurve(m.Thrustcurve)
+
 	// Initialize with first thrust point
 	m.thrust = m.Thrustcurve[0][1]
 	return m
@@ -79,16 +98,18 @@ func validateThrustCurve(curve [][]float64) [][]float64 {
 		}
 	}
 
-	// Ensure no negative thrust values
-	for _, point := range curve {
-		if point[1] < 0 {
-			panic("negative thrust values are invalid")
-		}
-	}
-
 	return curve
 }
 
+// integrateThrustCurve returns the total impulse of the curve using the trapezoidal rule
+func integrateThrustCurve(curve [][]float64) float64 {
+	var impulse float64
+	for i := 1; i < len(curve); i++ {
+		impulse += 0.5 * (curve[i][1] + curve[i-1][1]) * (curve[i][0] - curve[i-1][0])
+	}
+	return impulse
+}
+
 // Update updates the motor state based on the current time step
 func (m *Motor) Update(dt float64) error {
 	m.mu.Lock()
@@ -99,15 +120,22 @@ func (m *Motor) Update(dt float64) error {
 	}
 
 	// Update elapsed time first
+	previousTime := m.elapsedTime
 	m.elapsedTime += dt
 
-	// Check for burnout before updating thrust
+	// Check for burnout before updating thrust, crediting the impulse up to burnout
 	if m.elapsedTime >= m.burnTime {
+		var stepImpulse float64
+		if !m.isCoasting {
+			stepImpulse = m.impulseBetween(previousTime, m.burnTime)
+			m.impulse += stepImpulse
+		}
+		m.averageThrust = stepImpulse / dt
 		return m.handleBurnout()
 	}
 
 	// Update thrust and mass if not coasting
-	m.updateThrustAndMass(dt)
+	m.updateThrustAndMass(previousTime)
 
 	// Only try to ignite if we're in the initial state
 	if m.state == MotorIgnited {
@@ -126,6 +154,7 @@ func (m *Motor) handleBurnout() error {
 	if !m.isCoasting {
 		m.isCoasting = true
 		m.thrust = 0
+		m.Mass = m.Props.TotalMass - m.propellantMass
 
 		// Only attempt state transition if we're not already in burnout
 		if m.state != MotorBurnout {
@@ -140,19 +169,20 @@ func (m *Motor) handleBurnout() error {
 	return nil
 }
 
-func (m *Motor) updateThrustAndMass(dt float64) {
+func (m *Motor) updateThrustAndMass(previousTime float64) {
+	m.averageThrust = 0
 	if !m.isCoasting {
 		// Get current thrust from interpolation
 		m.thrust = m.interpolateThrust(m.elapsedTime)
 
-		// Calculate mass loss based on thrust and time step
-		if m.Mass > 0 && m.thrust > 0 {
-			// Calculate mass loss proportional to average thrust
-			propellantMassFlow := m.Props.TotalMass / m.burnTime
-			massLoss := propellantMassFlow * dt
-
-			// Ensure mass doesn't go below zero
-			m.Mass = math.Max(0, m.Mass-massLoss)
+		// Consume propellant in proportion to the impulse delivered so far, integrated
+		// over the step so it doesn't depend on where steps fall relative to curve points
+		stepImpulse := m.impulseBetween(previousTime, m.elapsedTime)
+		m.impulse += stepImpulse
+		m.averageThrust = stepImpulse / (m.elapsedTime - previousTime)
+		if m.totalImpulse > 0 {
+			burned := m.propellantMass * math.Min(1, m.impulse/m.totalImpulse)
+			m.Mass = m.Props.TotalMass - burned
 		}
 
 		// Update state if burning
@@ -162,7 +192,15 @@ func (m *Motor) updateThrustAndMass(dt float64) {
 	}
 }
 
-// GetThrust returns the current thrust of the motor
+// GetImpulse returns the impulse delivered so far
+func (m *Motor) GetImpulse() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.impulse
+}
+
+// GetThrust returns the current thrust of the motor, linearly interpolated from the
+// thrust curve at the elapsed time whatever the step size
 func (m *Motor) GetThrust() float64 {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -174,6 +212,15 @@ func (m *Motor) GetThrust() float64 {
 	return m.thrust
 }
 
+// GetAverageThrust returns the mean thrust over the last Update. Applied for that step it
+// delivers exactly the curve's impulse, so the total doesn't depend on the step size or on
+// where steps fall relative to the curve points, including the partial step at burnout.
+func (m *Motor) GetAverageThrust() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.averageThrust
+}
+
 // IsCoasting returns true if the motor has completed its burn
 func (m *Motor) IsCoasting() bool {
 	m.mu.RLock()
@@ -194,6 +241,8 @@ func (m *Motor) Reset() {
 	defer m.mu.Unlock()
 
 	m.elapsedTime = 0
+	m.impulse = 0
+	m.averageThrust = 0
 	m.isCoasting = false
 	m.thrust = m.Thrustcurve[0][1]
 	m.Mass = m.Props.TotalMass
@@ -228,6 +277,7 @@ func (m *Motor) interpolateThrust(totalDt float64) float64 {
 	if m.isCoasting || totalDt >= m.burnTime-eps {
 		m.isCoasting = true
 		m.thrust = 0
+		m.Mass = m.Props.TotalMass - m.propellantMass
 		m.state = MotorBurnout
 		return 0
 	}
@@ -254,6 +304,30 @@ func (m *Motor) interpolateThrust(totalDt float64) float64 {
 	return 0
 }
 
+// impulseBetween integrates the interpolated thrust from t0 to t1, exact for the piecewise linear curve
+func (m *Motor) impulseBetween(t0, t1 float64) float64 {
+	curve := m.Thrustcurve
+	var impulse float64
+
+	// The first point is held before the curve starts, matching interpolateThrust
+	if t0 < curve[0][0] {
+		impulse += curve[0][1] * (math.Min(t1, curve[0][0]) - t0)
+	}
+
+	for i := 1; i < len(curve); i++ {
+		start, end := math.Max(t0, curve[i-1][0]), math.Min(t1, curve[i][0])
+		if end <= start {
+			continue
+		}
+		slope := (curve[i][1] - curve[i-1][1]) / (curve[i][0] - curve[i-1][0])
+		thrustStart := curve[i-1][1] + slope*(start-curve[i-1][0])
+		thrustEnd := curve[i-1][1] + slope*(end-curve[i-1][0])
+		impulse += 0.5 * (thrustStart + thrustEnd) * (end - start)
+	}
+
+	return impulse
+}
+
 // String returns a string representation of the motor component
 func (m *Motor) String() string {
 	return fmt.Sprintf("Motor{ID: %d, Position: %s, Mass: %f, Thrust: %f}", m.ID.ID(), m.Position.String(), m.Mass, m.thrust)
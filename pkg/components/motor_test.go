@@ -59,16 +59,16 @@ func TestMotorUpdate(t *testing.T) {
 	err := motor.Update(0.5)
 	assert.NoError(t, err)
 
-	// After 0.5s, thrust should still be 10.0N and mass should be 7.5kg
+	// After 0.5s, thrust should still be 10.0N and 5 of 15Ns delivered burns a third of the mass
 	assert.Equal(t, 10.0, motor.GetThrust())
-	assert.Equal(t, 7.5, motor.GetMass())
+	assert.InDelta(t, 10.0-10.0*5.0/15.0, motor.GetMass(), 1e-9)
 
-	// Update to burnout
+	// Update to burnout, with no propellant mass given the whole motor mass is expended
 	err = motor.Update(1.5)
 	assert.NoError(t, err)
 
 	assert.Equal(t, 0.0, motor.GetThrust())
-	assert.Equal(t, 7.5, motor.GetMass())
+	assert.Equal(t, 0.0, motor.GetMass())
 }
 
 // TEST: GIVEN a Motor WHEN Update is called THEN the Motor is updated
@@ -129,3 +129,70 @@ func TestInvalidUpdate(t *testing.T) {
 	err := motor.Update(-0.1) // Invalid negative timestep
 	assert.Error(t, err)
 }
+
+// TEST: GIVEN a motor with known propellant mass WHEN it burns THEN mass follows the delivered impulse down to the dry mass
+func TestMotorMassDepletionFollowsImpulse(t *testing.T) {
+	logger := logf.New(logf.Opts{})
+	md := &thrustcurves.MotorData{
+		Thrust:    [][]float64{{0.0, 20.0}, {1.0, 20.0}, {2.0, 0.0}}, // 30Ns, 20Ns in the first second
+		TotalMass: 0.5,
+		WetMass:   0.2, // Propellant
+		BurnTime:  2.0,
+	}
+	motor := components.NewMotor(ecs.NewBasic(), md, logger)
+
+	previous := motor.GetMass()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, motor.Update(0.01))
+		assert.LessOrEqual(t, motor.GetMass(), previous, "Mass should never increase during the burn")
+		previous = motor.GetMass()
+	}
+
+	// Two thirds of the impulse has been delivered after one second
+	assert.InDelta(t, 0.5-0.2*(20.0/30.0), motor.GetMass(), 0.002)
+
+	for i := 0; i < 150; i++ {
+		require.NoError(t, motor.Update(0.01))
+	}
+
+	assert.True(t, motor.IsCoasting())
+	assert.InDelta(t, 0.3, motor.GetMass(), 1e-9, "Burnout mass should equal the dry mass")
+}
+
+// TEST: GIVEN timesteps that don't align with the thrust curve WHEN the motor burns out THEN the delivered impulse matches the curve
+func TestMotorImpulseIndependentOfTimestep(t *testing.T) {
+	logger := logf.New(logf.Opts{})
+	md := &thrustcurves.MotorData{
+		Thrust:    [][]float64{{0.0, 5.0}, {0.03, 40.0}, {0.31, 32.0}, {0.77, 25.0}, {1.3, 0.0}},
+		TotalMass: 0.2,
+		WetMass:   0.1,
+		BurnTime:  1.3,
+	}
+	// 0.03*22.5 + 0.28*36 + 0.46*28.5 + 0.53*12.5
+	want := 0.675 + 10.08 + 13.11 + 6.625
+
+	for _, dt := range []float64{0.0001, 0.001, 0.0037, 0.01, 0.013, 0.07, 0.29} {
+		motor := components.NewMotor(ecs.NewBasic(), md, logger)
+		for !motor.IsCoasting() {
+			require.NoError(t, motor.Update(dt))
+		}
+		assert.InDelta(t, want, motor.GetImpulse(), 1e-9, "dt=%v", dt)
+	}
+}
+
+// TEST: GIVEN a thrust curve with negative points WHEN NewMotor is called THEN they are clamped to zero instead of panicking
+func TestNewMotorClampsNegativeThrust(t *testing.T) {
+	logger := logf.New(logf.Opts{})
+	md := &thrustcurves.MotorData{
+		Thrust:    [][]float64{{0.0, 10.0}, {1.0, 5.0}, {1.5, -0.5}, {2.0, 0.0}},
+		TotalMass: 2.0,
+		BurnTime:  2.0,
+	}
+
+	motor := components.NewMotor(ecs.NewBasic(), md, logger)
+	assert.Equal(t, 0.0, motor.Thrustcurve[2][1])
+	assert.Equal(t, -0.5, md.Thrust[2][1], "Motor data is not modified")
+
+	require.NoError(t, motor.Update(1.5))
+	assert.Equal(t, 0.0, motor.GetThrust())
+}
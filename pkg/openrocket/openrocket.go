@@ -3,12 +3,42 @@ package openrocket
 import (
 	"archive/zip"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
 )
 
+// ErrNoEmbeddedMotor is returned when the archive does not carry a motor file
+var ErrNoEmbeddedMotor = errors.New("no embedded motor found")
+
+// VersionMismatchError is returned when the file was written by a different OpenRocket version than configured
+type VersionMismatchError struct {
+	Configured string
+	Detected   string
+}
+
+func (e *VersionMismatchError) Error() string {
+	return fmt.Sprintf("invalid OpenRocket version: file written by %s, configured %s", e.Detected, e.Configured)
+}
+
+// Load parses the .ork file and checks it was written by the configured OpenRocket version
 func Load(filename string, version string) (*OpenrocketDocument, error) {
+	doc, err := LoadAnyVersion(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// check version
+	if detected := doc.DetectedVersion(); detected != version {
+		return nil, &VersionMismatchError{Configured: version, Detected: detected}
+	}
+
+	return doc, nil
+}
+
+// LoadAnyVersion parses the .ork file whichever OpenRocket version wrote it
+func LoadAnyVersion(filename string) (*OpenrocketDocument, error) {
 	data, err := extractORK(filename)
 	if err != nil {
 		return nil, err
@@ -20,16 +50,29 @@ func Load(filename string, version string) (*OpenrocketDocument, error) {
 		return nil, err
 	}
 
-	// check version
-	if doc.Creator != fmt.Sprintf("OpenRocket %s", version) {
-		return nil, fmt.Errorf("invalid OpenRocket version: %s", doc.Creator)
-	}
-
 	return &doc, nil
 }
 
+// ExtractEmbeddedMotor returns the RASP (.eng) motor file packed in the archive alongside the .ork
+func ExtractEmbeddedMotor(filePath string) ([]byte, error) {
+	data, err := extractFile(filePath, ".eng")
+	if data == nil && err == nil {
+		return nil, ErrNoEmbeddedMotor
+	}
+	return data, err
+}
+
 // extractORK extracts the .ork file content from the zip archive
 func extractORK(filePath string) ([]byte, error) {
+	data, err := extractFile(filePath, ".ork")
+	if data == nil && err == nil {
+		return nil, fmt.Errorf("no .ork file found in the zip archive")
+	}
+	return data, err
+}
+
+// extractFile returns the first file in the zip archive with the given suffix, or nil if there is none
+func extractFile(filePath, suffix string) ([]byte, error) {
 	reader, err := zip.OpenReader(filePath)
 	if err != nil {
 		return nil, err
@@ -37,7 +80,7 @@ func extractORK(filePath string) ([]byte, error) {
 	defer reader.Close()
 
 	for _, f := range reader.File {
-		if strings.HasSuffix(f.Name, ".ork") {
+		if strings.HasSuffix(f.Name, suffix) {
 			rc, err := f.Open()
 			if err != nil {
 				return nil, err
@@ -51,5 +94,5 @@ func extractORK(filePath string) ([]byte, error) {
 			return data, nil
 		}
 	}
-	return nil, fmt.Errorf("no .ork file found in the zip archive")
+	return nil, nil
 }
@@ -1,6 +1,8 @@
 package openrocket_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/bxrne/launchrail/pkg/openrocket"
@@ -41,3 +43,46 @@ func TestLoadInvalidVersion(t *testing.T) {
 		t.Fatalf("Load did not return an error")
 	}
 }
+
+// TEST: GIVEN an OpenRocket file with a packed motor WHEN ExtractEmbeddedMotor is called THEN the motor file is returned
+func TestExtractEmbeddedMotor(t *testing.T) {
+	data, err := openrocket.ExtractEmbeddedMotor("../../testdata/openrocket/l1_embedded_motor.ork")
+	if err != nil {
+		t.Fatalf("ExtractEmbeddedMotor returned an error: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "; Test motor") {
+		t.Fatalf("unexpected motor file: %q", data)
+	}
+}
+
+// TEST: GIVEN an OpenRocket file without a packed motor WHEN ExtractEmbeddedMotor is called THEN ErrNoEmbeddedMotor is returned
+func TestExtractEmbeddedMotorMissing(t *testing.T) {
+	_, err := openrocket.ExtractEmbeddedMotor("../../testdata/openrocket/l1.ork")
+	if !errors.Is(err, openrocket.ErrNoEmbeddedMotor) {
+		t.Fatalf("expected ErrNoEmbeddedMotor, got %v", err)
+	}
+}
+
+// TEST: GIVEN a mismatched OpenRocket version WHEN Load is called THEN the error reports both versions
+func TestLoadVersionMismatch(t *testing.T) {
+	_, err := openrocket.Load("../../testdata/openrocket/l1.ork", "15.03")
+
+	var mismatch *openrocket.VersionMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected VersionMismatchError, got %v", err)
+	}
+	if mismatch.Configured != "15.03" || mismatch.Detected != "23.09" {
+		t.Fatalf("unexpected versions: %+v", mismatch)
+	}
+}
+
+// TEST: GIVEN any OpenRocket file WHEN LoadAnyVersion is called THEN the document and its detected version are returned
+func TestLoadAnyVersion(t *testing.T) {
+	doc, err := openrocket.LoadAnyVersion("../../testdata/openrocket/l1.ork")
+	if err != nil {
+		t.Fatalf("LoadAnyVersion returned an error: %v", err)
+	}
+	if doc.DetectedVersion() != "23.09" {
+		t.Fatalf("unexpected detected version: %s", doc.DetectedVersion())
+	}
+}
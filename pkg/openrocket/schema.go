@@ -3,6 +3,7 @@ package openrocket
 import (
 	"encoding/xml"
 	"fmt"
+	"strings"
 
 	"github.com/bxrne/launchrail/internal/config"
 )
@@ -46,6 +47,11 @@ func (o *OpenrocketDocument) String() string {
 	return fmt.Sprintf("OpenrocketDocument{Version=%s, Creator=%s, Rocket=%s}", o.Version, o.Creator, o.Rocket.String())
 }
 
+// DetectedVersion returns the OpenRocket version that wrote the document
+func (o *OpenrocketDocument) DetectedVersion() string {
+	return strings.TrimPrefix(o.Creator, "OpenRocket ")
+}
+
 // RocketDocument represents the rocket element of the XML document
 type RocketDocument struct {
 	XMLName            xml.Name           `xml:"rocket"`
@@ -60,6 +66,14 @@ type RocketDocument struct {
 	Subcomponents      Subcomponents      `xml:"subcomponents"`
 }
 
+// MotorMountTube returns the sustainer's inner tube that holds the motor
+func (r *RocketDocument) MotorMountTube() (*InnerTube, error) {
+	if len(r.Subcomponents.Stages) == 0 {
+		return nil, fmt.Errorf("rocket has no stages")
+	}
+	return &r.Subcomponents.Stages[0].SustainerSubcomponents.BodyTube.Subcomponents.InnerTube, nil
+}
+
 // String returns full string representation of the RocketDocument
 func (r *RocketDocument) String() string {
 	return fmt.Sprintf("RocketDocument{Name=%s, ID=%s, AxialOffset=%s, Position=%s, Designer=%s, Revision=%s, MotorConfiguration=%s, ReferenceType=%s, Subcomponents={%s}}", r.Name, r.ID, r.AxialOffset.String(), r.Position.String(), r.Designer, r.Revision, r.MotorConfiguration.String(), r.ReferenceType, r.Subcomponents.String())
@@ -84,6 +84,11 @@ type InnerTube struct {
 	Subcomponents        NoseSubcomponents `xml:"subcomponents"` // TODO: Refactor naming here
 }
 
+// InnerDiameter returns the bore of the inner tube, the widest motor it can take
+func (i *InnerTube) InnerDiameter() float64 {
+	return 2 * (i.OuterRadius - i.Thickness)
+}
+
 // String returns full string representation of the innertube
 func (i *InnerTube) String() string {
 	return fmt.Sprintf("InnerTube{Name=%s, ID=%s, AxialOffset=%s, Position=%s, Material=%s, Length=%.2f, RadialPosition=%.2f, RadialDirection=%.2f, OuterRadius=%.2f, Thickness=%.2f, ClusterConfiguration=%s, ClusterScale=%.2f, ClusterRotation=%.2f, MotorMount=%s, Subcomponents=%s}", i.Name, i.ID, i.AxialOffset.String(), i.Position.String(), i.Material.String(), i.Length, i.RadialPosition, i.RadialDirection, i.OuterRadius, i.Thickness, i.ClusterConfiguration, i.ClusterScale, i.ClusterRotation, i.MotorMount.String(), i.Subcomponents.String())
@@ -1,11 +1,16 @@
 package simulation
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 
 	"github.com/EngoEngine/ecs"
 	"github.com/bxrne/launchrail/internal/config"
 	"github.com/bxrne/launchrail/internal/storage"
+	"github.com/bxrne/launchrail/pkg/aerodeck"
+	"github.com/bxrne/launchrail/pkg/atmosphere"
 	"github.com/bxrne/launchrail/pkg/components"
 	"github.com/bxrne/launchrail/pkg/entities"
 	"github.com/bxrne/launchrail/pkg/openrocket"
@@ -16,6 +21,21 @@ import (
 	"github.com/zerodha/logf"
 )
 
+// ErrInsufficientThrust is returned when the motor cannot lift the rocket off the pad
+var ErrInsufficientThrust = errors.New("insufficient thrust-to-weight")
+
+// ErrMotorDoesNotFit is returned in strict mode when the motor is wider or longer than its mount
+var ErrMotorDoesNotFit = errors.New("motor does not fit the motor mount")
+
+// ErrStepTooCoarse is returned in strict mode when the step can't resolve the thrust curve
+var ErrStepTooCoarse = errors.New("simulation step too coarse for the motor")
+
+// minStepsPerBurn is the fewest steps a burn should span to resolve its thrust curve
+const minStepsPerBurn = 20
+
+// motorFitTolerance absorbs rounding in published motor and tube dimensions
+const motorFitTolerance = 0.0005 // m
+
 // Simulation represents a rocket simulation
 type Simulation struct {
 	world                 *ecs.World
@@ -25,19 +45,26 @@ type Simulation struct {
 	storageParasiteSystem *systems.StorageParasiteSystem
 	rulesSystem           *systems.RulesSystem
 	rocket                *entities.RocketEntity
+	motor                 *components.Motor
+	entity                *systems.PhysicsEntity
+	maxQDrag              systems.DragBreakdown // Drag buildup at the step of max-Q
 	config                *config.Config
 	logger                *logf.Logger
 	updateChan            chan struct{}
 	doneChan              chan struct{}
 	stateChan             chan systems.RocketState
 	stats                 *stats.FlightStats
+	isa                   *atmosphere.ISAModel
+	aerodeck              *aerodeck.Deck
+	motorData             *thrustcurves.MotorData
+	liftoffWeight         float64
 	launchRailSystem      *systems.LaunchRailSystem
 	currentTime           float64
 	systems               []systems.System // Now using the System interface
 }
 
 // NewSimulation creates a new rocket simulation
-func NewSimulation(cfg *config.Config, log *logf.Logger, motionStore *storage.Storage) (*Simulation, error) {
+func NewSimulation(cfg *config.Config, log *logf.Logger, motionStore storage.Store) (*Simulation, error) {
 	world := &ecs.World{}
 
 	sim := &Simulation{
@@ -49,28 +76,51 @@ func NewSimulation(cfg *config.Config, log *logf.Logger, motionStore *storage.St
 		stateChan:  make(chan systems.RocketState, 100), // Buffered channel
 	}
 
-	// Initialize systems with optimized worker counts
+	// Initialize systems, workers only help when there are several entities
 	sim.physicsSystem = systems.NewPhysicsSystem(world, cfg)
-	sim.aerodynamicSystem = systems.NewAerodynamicSystem(world, 4, cfg) // Add worker count
-	sim.rulesSystem = systems.NewRulesSystem(world)                     // Add this line
+	sim.aerodynamicSystem = systems.NewAerodynamicSystem(world, cfg)
+	sim.physicsSystem.SetAerodynamics(sim.aerodynamicSystem)
+	sim.rulesSystem = systems.NewRulesSystem(world, cfg)
+
+	if cfg.Options.AeroDeck != "" {
+		deck, err := aerodeck.Load(cfg.Options.AeroDeck)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load aero deck: %w", err)
+		}
+		sim.aerodeck = deck
+		sim.aerodynamicSystem.SetAeroDeck(deck)
+		minMach, maxMach := deck.MachRange()
+		log.Info("Using external aero deck", "file", cfg.Options.AeroDeck, "rows", len(deck.Points), "minMach", minMach, "maxMach", maxMach)
+	}
+
+	if dragArea := sim.aerodynamicSystem.GetParasiticDragArea(); dragArea > 0 {
+		log.Info("Parasitic drag from protrusions", "count", len(cfg.Options.Protrusions), "dragArea", dragArea)
+	}
 
 	// Initialize launch rail system with config values
 	sim.launchRailSystem = systems.NewLaunchRailSystem(
 		world,
-		cfg.Options.Launchrail.Length,
+		float64(cfg.Options.Launchrail.Length),
 		cfg.Options.Launchrail.Angle,
 		cfg.Options.Launchrail.Orientation,
 	)
 
 	// Initialize parasite systems
 	sim.logParasiteSystem = systems.NewLogParasiteSystem(world, log)
-	sim.storageParasiteSystem = systems.NewStorageParasiteSystem(world, motionStore)
+	var gravity float64
+	if cfg.Storage.AccelerationG {
+		gravity = cfg.Options.Launchsite.Atmosphere.ISAConfiguration.GravitationalAccel
+	}
 
-	// Start parasites
+	// Start parasites, a nil store disables its parasite
 	sim.logParasiteSystem.Start(sim.stateChan)
-	sim.storageParasiteSystem.Start(sim.stateChan)
+	if motionStore != nil {
+		sim.storageParasiteSystem = systems.NewStorageParasiteSystem(world, motionStore, cfg.Storage.Precision, gravity)
+		sim.storageParasiteSystem.Start(sim.stateChan)
+	}
 
-	sim.stats = stats.NewFlightStats()
+	sim.stats = stats.NewFlightStats(stats.ApogeeMethod(cfg.Reporting.ApogeeMethod))
+	sim.isa = atmosphere.NewModel(&cfg.Options.Launchsite.Atmosphere)
 
 	// Add systems to the slice
 	sim.systems = []systems.System{
@@ -79,7 +129,9 @@ func NewSimulation(cfg *config.Config, log *logf.Logger, motionStore *storage.St
 		sim.rulesSystem,
 		sim.launchRailSystem,
 		sim.logParasiteSystem,
-		sim.storageParasiteSystem,
+	}
+	if sim.storageParasiteSystem != nil {
+		sim.systems = append(sim.systems, sim.storageParasiteSystem)
 	}
 
 	return sim, nil
@@ -87,11 +139,17 @@ func NewSimulation(cfg *config.Config, log *logf.Logger, motionStore *storage.St
 
 // LoadRocket loads a rocket entity into the simulation
 func (s *Simulation) LoadRocket(orkData *openrocket.RocketDocument, motorData *thrustcurves.MotorData) error {
+	if err := s.checkMotorFit(orkData, motorData); err != nil {
+		return err
+	}
+
 	// Create motor component with logger
 	motor := components.NewMotor(ecs.NewBasic(), motorData, *s.logger)
 
 	// Create rocket entity with all components
 	s.rocket = entities.NewRocketEntity(s.world, orkData, motor)
+	s.motor = motor
+	s.motorData = motorData
 
 	// Create a single PhysicsEntity to reuse for all systems
 	sysEntity := &systems.PhysicsEntity{
@@ -106,13 +164,17 @@ func (s *Simulation) LoadRocket(orkData *openrocket.RocketDocument, motorData *t
 		Finset:       s.rocket.GetComponent("finset").(*components.TrapezoidFinset),
 	}
 
+	s.entity = sysEntity
+
 	// Add to all systems
 	s.physicsSystem.Add(sysEntity)
 	s.aerodynamicSystem.Add(sysEntity)
 	s.rulesSystem.Add(sysEntity)
 	s.launchRailSystem.Add(sysEntity)
 	s.logParasiteSystem.Add(sysEntity)
-	s.storageParasiteSystem.Add(sysEntity)
+	if s.storageParasiteSystem != nil {
+		s.storageParasiteSystem.Add(sysEntity)
+	}
 
 	return nil
 }
@@ -121,7 +183,9 @@ func (s *Simulation) LoadRocket(orkData *openrocket.RocketDocument, motorData *t
 func (s *Simulation) Run() error {
 	defer func() {
 		s.logParasiteSystem.Stop()
-		s.storageParasiteSystem.Stop()
+		if s.storageParasiteSystem != nil {
+			s.storageParasiteSystem.Stop()
+		}
 	}()
 
 	// Validate simulation parameters
@@ -132,27 +196,267 @@ func (s *Simulation) Run() error {
 		return fmt.Errorf("invalid max time: must be between 0 and 120")
 	}
 
+	// Abort early rather than sit on the pad until max time
+	if err := s.checkThrustToWeight(); err != nil {
+		return err
+	}
+
+	// Short burns are silently misintegrated by a coarse step
+	if err := s.checkStepResolution(); err != nil {
+		return err
+	}
+
 	for s.currentTime < s.config.Simulation.MaxTime {
 		if err := s.updateSystems(); err != nil {
 			return err
 		}
+
+		// Descent is of no interest to apogee studies
+		if s.config.Simulation.StopAtApogee && s.rulesSystem.HadApogee() {
+			s.stats.MarkAscentOnly()
+			s.logger.Info("Stopping at apogee, record is ascent-only",
+				"time", s.currentTime,
+				"altitude", s.rocket.Position.Y)
+			break
+		}
 		s.currentTime += s.config.Simulation.Step
 	}
 
-	s.logger.Warn("Simulation reached max time without landing",
-		"maxTime", s.config.Simulation.MaxTime,
-		"finalAltitude", s.rocket.Position.Y)
+	if !s.stats.AscentOnly {
+		s.logger.Warn("Simulation reached max time without landing",
+			"maxTime", s.config.Simulation.MaxTime,
+			"finalAltitude", s.rocket.Position.Y)
+	}
 
 	// Print stats even if max time reached
 	s.logger.Info("Flight Statistics",
 		"stats", s.stats.String(),
 	)
+	if s.config.Storage.AccelerationG {
+		s.logger.Info("Max acceleration", "g", s.stats.MaxAccel/s.config.Options.Launchsite.Atmosphere.ISAConfiguration.GravitationalAccel)
+	}
+
+	// Global maxima are only known once the run is complete
+	if s.config.Simulation.EmitMaxEvents {
+		s.emitMaxEvents()
+	}
+	s.logHeating()
+	s.logDragBuildup()
+	s.checkAeroDeckCoverage()
+	s.logDelayRecommendation()
 
 	close(s.doneChan)
 	return nil
 }
 
+// checkAeroDeckCoverage warns when the flight left the Mach range of the aero deck
+func (s *Simulation) checkAeroDeckCoverage() {
+	if s.aerodeck == nil || s.aerodeck.Covers(s.stats.MaxMach) {
+		return
+	}
+
+	minMach, maxMach := s.aerodeck.MachRange()
+	s.logger.Warn("Aero deck extrapolated beyond its Mach range",
+		"maxMach", s.stats.MaxMach,
+		"deckMinMach", minMach,
+		"deckMaxMach", maxMach)
+}
+
+// logDelayRecommendation recommends the ejection delay closest to the coast time to apogee
+func (s *Simulation) logDelayRecommendation() {
+	if s.liftoffWeight > 0 {
+		s.logger.Info("Impulse to liftoff weight", "ratio", s.motorData.TotalImpulse/s.liftoffWeight)
+	}
+
+	coastTime := s.stats.TimeToApogee - s.motorData.BurnTime
+	if coastTime <= 0 {
+		return
+	}
+
+	delay, inRange, err := s.motorData.RecommendDelay(coastTime)
+	if err != nil {
+		s.logger.Info("No ejection delay recommendation", "coastTime", coastTime, "error", err)
+		return
+	}
+
+	s.logger.Info("Ejection delay recommendation",
+		"coastTime", coastTime,
+		"delay", delay,
+		"available", s.motorData.Delays)
+	if !inRange {
+		s.logger.Warn("Coast to apogee is outside the motor's delays, deployment will be early or late",
+			"coastTime", coastTime,
+			"minDelay", s.motorData.Delays[0],
+			"maxDelay", s.motorData.Delays[len(s.motorData.Delays)-1])
+	}
+}
+
+// checkStepResolution compares the step to the burn time and the thrust curve's point spacing
+func (s *Simulation) checkStepResolution() error {
+	curve := s.motorData.Thrust
+	if len(curve) < 2 {
+		return nil
+	}
+
+	burnTime := s.motorData.BurnTime
+	if burnTime <= 0 {
+		burnTime = curve[len(curve)-1][0] - curve[0][0]
+	}
+
+	spacings := make([]float64, 0, len(curve)-1)
+	for i := 1; i < len(curve); i++ {
+		if dt := curve[i][0] - curve[i-1][0]; dt > 0 {
+			spacings = append(spacings, dt)
+		}
+	}
+	if len(spacings) == 0 {
+		return nil
+	}
+	sort.Float64s(spacings)
+	spacing := spacings[len(spacings)/2]
+
+	step := s.config.Simulation.Step
+	maxStep := math.Min(burnTime/minStepsPerBurn, spacing)
+	if step <= maxStep {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: step %.4fs exceeds %.4fs (burn time %.3fs, median thrust point spacing %.4fs)", ErrStepTooCoarse, step, maxStep, burnTime, spacing)
+	if s.config.Simulation.StrictStep {
+		return err
+	}
+	s.logger.Warn("Simulation step may miss thrust curve detail", "error", err)
+	return nil
+}
+
+// checkThrustToWeight compares the motor's peak thrust to the liftoff weight
+func (s *Simulation) checkThrustToWeight() error {
+	minRatio := s.config.Simulation.MinThrustToWeight
+	if minRatio == 0 {
+		minRatio = 1
+	}
+
+	var peakThrust float64
+	for _, point := range s.motor.Thrustcurve {
+		peakThrust = math.Max(peakThrust, point[1])
+	}
+
+	weight := (s.rocket.Mass.Value + s.motor.GetMass()) * s.config.Options.Launchsite.Atmosphere.ISAConfiguration.GravitationalAccel
+	if weight <= 0 {
+		return nil
+	}
+	s.liftoffWeight = weight
+
+	if ratio := peakThrust / weight; ratio < minRatio {
+		return fmt.Errorf("%w: %.2f is below the minimum of %.2f", ErrInsufficientThrust, ratio, minRatio)
+	}
+	return nil
+}
+
+// checkMotorFit compares the motor's dimensions to the motor mount bore and length, plus any overhang
+func (s *Simulation) checkMotorFit(orkData *openrocket.RocketDocument, motorData *thrustcurves.MotorData) error {
+	if motorData.Diameter <= 0 || motorData.Length <= 0 {
+		return nil // Dimensions unknown
+	}
+
+	mount, err := orkData.MotorMountTube()
+	if err != nil || mount.Length <= 0 {
+		return nil // No mount to check against
+	}
+
+	diameterClearance := mount.InnerDiameter() - motorData.Diameter
+	lengthClearance := mount.Length + mount.MotorMount.Overhang - motorData.Length
+	s.logger.Info("Motor mount clearance", "diameter", diameterClearance, "length", lengthClearance)
+
+	if diameterClearance >= -motorFitTolerance && lengthClearance >= -motorFitTolerance {
+		return nil
+	}
+
+	err = fmt.Errorf("%w: diameter clearance %.4fm, length clearance %.4fm", ErrMotorDoesNotFit, diameterClearance, lengthClearance)
+	if s.config.Options.StrictMotorFit {
+		return err
+	}
+	s.logger.Warn("Motor mount mismatch", "error", err)
+	return nil
+}
+
+// GetStats returns the flight statistics gathered during the run
+func (s *Simulation) GetStats() *stats.FlightStats {
+	return s.stats
+}
+
+// GetTimeline returns the flight events with their times, in order
+func (s *Simulation) GetTimeline() []systems.TimedEvent {
+	return s.rulesSystem.GetTimeline()
+}
+
+// emitMaxEvents adds the max velocity and max acceleration events to the timeline at their timestamps
+func (s *Simulation) emitMaxEvents() {
+	s.rulesSystem.InsertEvent(systems.MaxVelocity, s.stats.TimeToMaxVelocity)
+	s.rulesSystem.InsertEvent(systems.MaxAcceleration, s.stats.TimeToMaxAccel)
+
+	s.logger.Info("Flight event",
+		"event", systems.MaxVelocity.String(),
+		"time", s.stats.TimeToMaxVelocity,
+		"value", s.stats.MaxVelocity,
+	)
+	s.logger.Info("Flight event",
+		"event", systems.MaxAcceleration.String(),
+		"time", s.stats.TimeToMaxAccel,
+		"value", s.stats.MaxAccel,
+	)
+}
+
+// logHeating reports the aerodynamic heating estimates, skipped when the rocket never moved
+func (s *Simulation) logHeating() {
+	if s.stats.MaxQ == 0 {
+		return
+	}
+
+	s.logger.Info("Aerodynamic heating at max-Q",
+		"time", s.stats.TimeToMaxQ,
+		"dynamicPressure", s.stats.MaxQ,
+		"mach", s.stats.MaxQHeating.Mach,
+		"stagnationTemperature", s.stats.MaxQHeating.StagnationTemperature,
+		"recoveryTemperature", s.stats.MaxQHeating.RecoveryTemperature,
+	)
+	s.logger.Info("Aerodynamic heating at max speed",
+		"time", s.stats.TimeToMaxVelocity,
+		"mach", s.stats.MaxSpeedHeating.Mach,
+		"stagnationTemperature", s.stats.MaxSpeedHeating.StagnationTemperature,
+		"recoveryTemperature", s.stats.MaxSpeedHeating.RecoveryTemperature,
+	)
+}
+
+// logDragBuildup reports the body and protrusion drag at max-Q, skipped when the rocket never moved
+func (s *Simulation) logDragBuildup() {
+	if s.stats.MaxQ == 0 {
+		return
+	}
+
+	s.logger.Info("Drag buildup at max-Q",
+		"time", s.stats.TimeToMaxQ,
+		"bodyDrag", s.maxQDrag.Body,
+		"bodyCdA", s.maxQDrag.BodyCdA,
+		"protrusionDrag", s.maxQDrag.Protrusions,
+		"protrusionCdA", s.maxQDrag.ProtrusionsCdA,
+		"protrusionShare", s.maxQDrag.ProtrusionShare(),
+	)
+}
+
+// GetMaxQDrag returns the drag buildup recorded at max-Q
+func (s *Simulation) GetMaxQDrag() systems.DragBreakdown {
+	return s.maxQDrag
+}
+
 func (s *Simulation) updateSystems() error {
+	// Advance the motor first so the systems apply the thrust averaged over this step
+	if s.motor != nil {
+		if err := s.motor.Update(s.config.Simulation.Step); err != nil {
+			return err
+		}
+	}
+
 	for _, system := range s.systems {
 		if err := system.Update(float32(s.config.Simulation.Step)); err != nil {
 			return err
@@ -160,12 +464,24 @@ func (s *Simulation) updateSystems() error {
 	}
 
 	// Update flight stats
+	mach := s.isa.GetMach(s.rocket.Velocity.Y, s.rocket.Position.Y)
 	s.stats.Update(
 		s.currentTime,
 		s.rocket.Position.Y,
 		s.rocket.Velocity.Y,
 		s.rocket.Acceleration.Y,
-		s.rocket.Velocity.Y/float64(s.aerodynamicSystem.GetSpeedOfSound(float32(s.rocket.Position.Y))),
+		mach,
+	)
+
+	atm := s.isa.GetAtmosphere(s.rocket.Position.Y)
+	dynamicPressure := 0.5 * atm.Density * s.rocket.Velocity.Y * s.rocket.Velocity.Y
+	if dynamicPressure > s.stats.MaxQ && s.entity != nil {
+		s.maxQDrag = s.aerodynamicSystem.DragBreakdown(*s.entity)
+	}
+	s.stats.UpdateHeating(
+		s.currentTime,
+		dynamicPressure,
+		stats.EstimateHeating(atm.Temperature, mach, s.config.Options.Launchsite.Atmosphere.ISAConfiguration.RatioSpecificHeats),
 	)
 
 	return nil
@@ -8,6 +8,7 @@ import (
 	"github.com/bxrne/launchrail/internal/storage"
 	"github.com/bxrne/launchrail/pkg/openrocket"
 	"github.com/bxrne/launchrail/pkg/simulation"
+	"github.com/bxrne/launchrail/pkg/systems"
 	"github.com/bxrne/launchrail/pkg/thrustcurves"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -163,6 +164,24 @@ func TestRun(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TEST: GIVEN no motion store WHEN a simulation is created and run THEN it runs without storing
+func TestRun_NoMotionStore(t *testing.T) {
+	cfg, logger, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	sim, err := simulation.NewSimulation(cfg, logger, nil)
+	require.NoError(t, err)
+
+	motorData := &thrustcurves.MotorData{
+		ID:        "test-motor",
+		Thrust:    [][]float64{{0, 100}, {1, 0}},
+		TotalMass: 0.1,
+	}
+	require.NoError(t, sim.LoadRocket(createTestRocketData(), motorData))
+
+	assert.NoError(t, sim.Run())
+}
+
 // TEST: GIVEN invalid simulation parameters WHEN Run is called THEN returns error
 func TestRun_InvalidParameters(t *testing.T) {
 	cfg, logger, store, cleanup := setupTest(t)
@@ -189,3 +208,144 @@ func TestRun_InvalidParameters(t *testing.T) {
 	err = sim.Run()
 	assert.Error(t, err)
 }
+
+// TEST: GIVEN max events are enabled WHEN Run is called THEN the max velocity and acceleration events are in the timeline at their stats times
+func TestRun_EmitMaxEvents(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		cfg, logger, store, cleanup := setupTest(t)
+		defer cleanup()
+
+		cfg.Simulation.Step = 0.01
+		cfg.Simulation.MaxTime = 1.0
+		cfg.Simulation.EmitMaxEvents = enabled
+
+		sim, err := simulation.NewSimulation(cfg, logger, store)
+		require.NoError(t, err)
+
+		motorData := &thrustcurves.MotorData{
+			ID:          "test-motor",
+			Designation: "H123",
+			Thrust:      [][]float64{{0, 100}, {1, 0}},
+			TotalMass:   0.1,
+		}
+
+		err = sim.LoadRocket(createTestRocketData(), motorData)
+		require.NoError(t, err)
+
+		err = sim.Run()
+		require.NoError(t, err)
+
+		if !enabled {
+			assert.Empty(t, sim.GetTimeline())
+			continue
+		}
+		stats := sim.GetStats()
+		assert.Equal(t, []systems.TimedEvent{
+			{Event: systems.MaxVelocity, Time: stats.TimeToMaxVelocity},
+			{Event: systems.MaxAcceleration, Time: stats.TimeToMaxAccel},
+		}, sim.GetTimeline())
+	}
+}
+
+// TEST: GIVEN a motor too weak to lift the rocket WHEN Run is called THEN the run aborts with ErrInsufficientThrust
+func TestRun_InsufficientThrust(t *testing.T) {
+	cfg, logger, store, cleanup := setupTest(t)
+	defer cleanup()
+
+	sim, err := simulation.NewSimulation(cfg, logger, store)
+	require.NoError(t, err)
+
+	motorData := &thrustcurves.MotorData{
+		ID:          "test-motor",
+		Designation: "A1",
+		Thrust:      [][]float64{{0, 0.5}, {1, 0}},
+		TotalMass:   0.1, // ~0.98N liftoff weight
+	}
+
+	err = sim.LoadRocket(createTestRocketData(), motorData)
+	require.NoError(t, err)
+
+	err = sim.Run()
+	assert.ErrorIs(t, err, simulation.ErrInsufficientThrust)
+}
+
+// TEST: GIVEN a motor wider than its mount WHEN LoadRocket is called THEN it warns by default and fails in strict mode
+func TestLoadRocket_MotorFit(t *testing.T) {
+	tests := []struct {
+		name     string
+		diameter float64
+		length   float64
+		strict   bool
+		wantErr  bool
+	}{
+		{"Fits", 0.029, 0.2, true, false},
+		{"Too wide warns", 0.038, 0.2, false, false},
+		{"Too wide strict", 0.038, 0.2, true, true},
+		{"Too long strict", 0.029, 0.3, true, true},
+		{"Overhang allowed", 0.029, 0.26, true, false},
+		{"Unknown dimensions", 0, 0, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, logger, store, cleanup := setupTest(t)
+			defer cleanup()
+			cfg.Options.StrictMotorFit = tt.strict
+
+			sim, err := simulation.NewSimulation(cfg, logger, store)
+			require.NoError(t, err)
+
+			// 29mm bore, 0.25m long with 0.02m overhang
+			orkData := createTestRocketData()
+			orkData.Subcomponents.Stages[0].SustainerSubcomponents.BodyTube.Subcomponents.InnerTube = openrocket.InnerTube{
+				Length:      0.25,
+				OuterRadius: 0.0155,
+				Thickness:   0.001,
+				MotorMount:  openrocket.MotorMount{Overhang: 0.02},
+			}
+			motorData := &thrustcurves.MotorData{
+				ID:        "test-motor",
+				Thrust:    [][]float64{{0, 100}, {1, 0}},
+				TotalMass: 0.1,
+				Diameter:  tt.diameter,
+				Length:    tt.length,
+			}
+
+			err = sim.LoadRocket(orkData, motorData)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, simulation.ErrMotorDoesNotFit)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TEST: GIVEN a step too coarse for a short burn WHEN Run is called THEN it warns by default and fails in strict mode
+func TestRun_StepTooCoarse(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		cfg, logger, store, cleanup := setupTest(t)
+		cfg.Simulation.Step = 0.01
+		cfg.Simulation.StrictStep = strict
+
+		sim, err := simulation.NewSimulation(cfg, logger, store)
+		require.NoError(t, err)
+
+		// 0.1s burn needs a step of at most 5ms
+		motorData := &thrustcurves.MotorData{
+			ID:        "test-motor",
+			Thrust:    [][]float64{{0, 0}, {0.02, 100}, {0.05, 100}, {0.1, 0}},
+			BurnTime:  0.1,
+			TotalMass: 0.1,
+		}
+		require.NoError(t, sim.LoadRocket(createTestRocketData(), motorData))
+
+		err = sim.Run()
+		if strict {
+			assert.ErrorIs(t, err, simulation.ErrStepTooCoarse)
+		} else {
+			assert.NotErrorIs(t, err, simulation.ErrStepTooCoarse)
+		}
+		cleanup()
+	}
+}
@@ -6,6 +6,19 @@ import (
 	"sync"
 )
 
+// ApogeeMethod selects how apogee is detected from the flight data
+type ApogeeMethod string
+
+// ApogeeMethod constants
+const (
+	ApogeeMax      ApogeeMethod = "max"      // Highest altitude sample
+	ApogeeVelocity ApogeeMethod = "velocity" // First positive to negative vertical velocity crossing
+	ApogeeSmoothed ApogeeMethod = "smoothed" // Peak of the moving average altitude
+)
+
+// smoothingWindow is the number of samples averaged by the smoothed apogee method
+const smoothingWindow = 5
+
 // FlightStats represents statistics for a rocket flight
 type FlightStats struct {
 	mu                sync.RWMutex
@@ -14,13 +27,29 @@ type FlightStats struct {
 	MaxAccel          float64
 	BurnTime          float64
 	TimeToApogee      float64
+	TimeToMaxVelocity float64
+	TimeToMaxAccel    float64
 	TotalFlightTime   float64
 	MaxMach           float64
 	GroundHitVelocity float64
+	MaxQ              float64
+	TimeToMaxQ        float64
+	MaxQHeating       ThermalData
+	MaxSpeedHeating   ThermalData
+	AscentOnly        bool // The run stopped at apogee, there are no descent metrics
+	apogeeMethod      ApogeeMethod
+	apogeeFound       bool
+	lastVelocity      float64
+	window            []float64 // Recent altitudes for the smoothed method
+	windowTimes       []float64
 }
 
 // NewFlightStats creates a new FlightStats object
-func NewFlightStats() *FlightStats {
+func NewFlightStats(apogeeMethod ApogeeMethod) *FlightStats {
+	if apogeeMethod == "" {
+		apogeeMethod = ApogeeMax
+	}
+
 	return &FlightStats{
 		Apogee:            0,
 		MaxVelocity:       0,
@@ -28,8 +57,13 @@ func NewFlightStats() *FlightStats {
 		MaxMach:           0,
 		BurnTime:          0,
 		TimeToApogee:      0,
+		TimeToMaxVelocity: 0,
+		TimeToMaxAccel:    0,
 		TotalFlightTime:   0,
 		GroundHitVelocity: 0,
+		apogeeMethod:      apogeeMethod,
+		window:            make([]float64, 0, smoothingWindow),
+		windowTimes:       make([]float64, 0, smoothingWindow),
 	}
 }
 
@@ -39,14 +73,17 @@ func (s *FlightStats) Update(time, altitude, velocity, accel, mach float64) {
 	defer s.mu.Unlock()
 
 	// Update apogee
-	if altitude > s.Apogee {
-		s.Apogee = altitude
-		s.TimeToApogee = time
-	}
+	s.updateApogee(time, altitude, velocity)
 
-	// Update maximums using absolute values
-	s.MaxVelocity = math.Max(s.MaxVelocity, math.Abs(velocity))
-	s.MaxAccel = math.Max(s.MaxAccel, math.Abs(accel))
+	// Update maximums using absolute values, tracking when they occur
+	if math.Abs(velocity) > s.MaxVelocity {
+		s.MaxVelocity = math.Abs(velocity)
+		s.TimeToMaxVelocity = time
+	}
+	if math.Abs(accel) > s.MaxAccel {
+		s.MaxAccel = math.Abs(accel)
+		s.TimeToMaxAccel = time
+	}
 	s.MaxMach = math.Max(s.MaxMach, math.Abs(mach))
 	s.TotalFlightTime = time
 
@@ -56,10 +93,73 @@ func (s *FlightStats) Update(time, altitude, velocity, accel, mach float64) {
 	}
 }
 
+// UpdateHeating records the heating estimate at max-Q and at the max velocity sample
+func (s *FlightStats) UpdateHeating(time, dynamicPressure float64, heating ThermalData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if dynamicPressure > s.MaxQ {
+		s.MaxQ = dynamicPressure
+		s.TimeToMaxQ = time
+		s.MaxQHeating = heating
+	}
+	if time == s.TimeToMaxVelocity && s.MaxVelocity > 0 {
+		s.MaxSpeedHeating = heating
+	}
+}
+
+// updateApogee applies the configured apogee detection method to a sample
+func (s *FlightStats) updateApogee(time, altitude, velocity float64) {
+	switch s.apogeeMethod {
+	case ApogeeVelocity:
+		if !s.apogeeFound && s.lastVelocity > 0 && velocity <= 0 {
+			s.Apogee = altitude
+			s.TimeToApogee = time
+			s.apogeeFound = true
+		}
+		s.lastVelocity = velocity
+	case ApogeeSmoothed:
+		if len(s.window) == smoothingWindow {
+			s.window = s.window[1:]
+			s.windowTimes = s.windowTimes[1:]
+		}
+		s.window = append(s.window, altitude)
+		s.windowTimes = append(s.windowTimes, time)
+		if len(s.window) < smoothingWindow {
+			return
+		}
+
+		var sum float64
+		for _, alt := range s.window {
+			sum += alt
+		}
+		if mean := sum / float64(len(s.window)); mean > s.Apogee {
+			s.Apogee = mean
+			s.TimeToApogee = s.windowTimes[len(s.windowTimes)/2]
+		}
+	default:
+		if altitude > s.Apogee {
+			s.Apogee = altitude
+			s.TimeToApogee = time
+		}
+	}
+}
+
+// MarkAscentOnly records that the run stopped at apogee
+func (s *FlightStats) MarkAscentOnly() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.AscentOnly = true
+}
+
 // String returns a string representation of the flight statistics
 func (s *FlightStats) String() string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	if s.AscentOnly {
+		return fmt.Sprintf("Apogee=%.2fm, MaxVelocity=%.2fm/s, MaxAccel=%.2fm/s², MaxMach=%.2f, AscentOnly=true", s.Apogee, s.MaxVelocity, s.MaxAccel, s.MaxMach)
+	}
 	return fmt.Sprintf("Apogee=%.2fm, MaxVelocity=%.2fm/s, MaxAccel=%.2fm/s², MaxMach=%.2f, GroundHitVelocity=%.2fm/s", s.Apogee, s.MaxVelocity, s.MaxAccel, s.MaxMach, s.GroundHitVelocity)
 }
@@ -1,6 +1,7 @@
 package stats_test
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/bxrne/launchrail/pkg/stats"
@@ -9,13 +10,13 @@ import (
 
 // TEST: GIVEN nothing WHEN NewFlightStats is called THEN a new FlightStats is returned
 func TestNewFlightStats(t *testing.T) {
-	fs := stats.NewFlightStats()
+	fs := stats.NewFlightStats(stats.ApogeeMax)
 	assert.NotNil(t, fs)
 }
 
 // TEST: GIVEN a FlightStats WHEN Update is called THEN the FlightStats is updated
 func TestFlightStatsUpdate(t *testing.T) {
-	fs := stats.NewFlightStats()
+	fs := stats.NewFlightStats(stats.ApogeeMax)
 	fs.Update(1.0, 100.0, 10.0, 1.0, 0.1)
 	assert.Equal(t, 100.0, fs.Apogee)
 	assert.Equal(t, 10.0, fs.MaxVelocity)
@@ -26,9 +27,75 @@ func TestFlightStatsUpdate(t *testing.T) {
 
 // TEST: GIVEN a FlightStats WHEN String is called THEN a string representation is returned
 func TestFlightStatsString(t *testing.T) {
-	fs := stats.NewFlightStats()
+	fs := stats.NewFlightStats(stats.ApogeeMax)
 	fs.Update(1.0, 100.0, 10.0, 1.0, 0.1)
 	assert.NotEmpty(t, fs.String())
 	expected := "Apogee=100.00m, MaxVelocity=10.00m/s, MaxAccel=1.00m/s², MaxMach=0.10, GroundHitVelocity=0.00m/s"
 	assert.Equal(t, expected, fs.String())
 }
+
+// TEST: GIVEN an ascent-only FlightStats WHEN String is called THEN no descent metrics are reported
+func TestFlightStatsStringAscentOnly(t *testing.T) {
+	fs := stats.NewFlightStats(stats.ApogeeMax)
+	fs.Update(1.0, 100.0, 10.0, 1.0, 0.1)
+	fs.MarkAscentOnly()
+	assert.True(t, fs.AscentOnly)
+	expected := "Apogee=100.00m, MaxVelocity=10.00m/s, MaxAccel=1.00m/s², MaxMach=0.10, AscentOnly=true"
+	assert.Equal(t, expected, fs.String())
+}
+
+// TEST: GIVEN a FlightStats WHEN Update is called with rising then falling values THEN the time of each maximum is recorded
+func TestFlightStatsTimeOfMaxima(t *testing.T) {
+	fs := stats.NewFlightStats(stats.ApogeeMax)
+	fs.Update(0.5, 10.0, 50.0, 120.0, 0.1)
+	fs.Update(1.0, 40.0, 80.0, 60.0, 0.2)
+	fs.Update(1.5, 70.0, 60.0, -9.8, 0.2)
+	assert.Equal(t, 1.0, fs.TimeToMaxVelocity)
+	assert.Equal(t, 0.5, fs.TimeToMaxAccel)
+}
+
+// noisyFlight feeds a ballistic trajectory (apogee 510.2m at 10.2s) with altimeter noise and a late spike
+func noisyFlight(fs *stats.FlightStats) {
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i <= 400; i++ {
+		t := float64(i) * 0.05
+		altitude := 100*t - 4.9*t*t + rng.NormFloat64()
+		velocity := 100 - 9.8*t + rng.NormFloat64()*0.1
+		if i == 240 {
+			altitude += 25 // Pressure spike well after apogee
+		}
+		fs.Update(t, altitude, velocity, -9.8, 0)
+	}
+}
+
+// TEST: GIVEN noisy flight data WHEN apogee is detected with each method THEN robust methods reject the spike
+func TestFlightStatsApogeeMethods(t *testing.T) {
+	tests := []struct {
+		method   stats.ApogeeMethod
+		wantAlt  float64
+		altDelta float64
+		wantTime float64
+	}{
+		{stats.ApogeeMax, 519.4, 3.0, 12.0},
+		{stats.ApogeeVelocity, 510.2, 3.0, 10.2},
+		{stats.ApogeeSmoothed, 510.2, 3.0, 10.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			fs := stats.NewFlightStats(tt.method)
+			noisyFlight(fs)
+			assert.InDelta(t, tt.wantAlt, fs.Apogee, tt.altDelta)
+			assert.InDelta(t, tt.wantTime, fs.TimeToApogee, 0.5)
+		})
+	}
+}
+
+// TEST: GIVEN no apogee method WHEN NewFlightStats is called THEN the max altitude method is used
+func TestFlightStatsDefaultApogeeMethod(t *testing.T) {
+	fs := stats.NewFlightStats("")
+	fs.Update(1.0, 100.0, 10.0, 1.0, 0.1)
+	fs.Update(2.0, 90.0, -10.0, 1.0, 0.1)
+	assert.Equal(t, 100.0, fs.Apogee)
+	assert.Equal(t, 1.0, fs.TimeToApogee)
+}
@@ -0,0 +1,24 @@
+package stats
+
+// turbulentRecoveryFactor is the recovery factor for a turbulent boundary layer, Pr^(1/3) for air
+const turbulentRecoveryFactor = 0.89
+
+// ThermalData is a rough aerodynamic heating estimate at a point in the flight
+type ThermalData struct {
+	Mach                  float64
+	StaticTemperature     float64 // K
+	StagnationTemperature float64 // K
+	RecoveryTemperature   float64 // K, approximate adiabatic wall temperature
+}
+
+// EstimateHeating computes stagnation and recovery temperatures from the static temperature and Mach
+func EstimateHeating(staticTemperature, mach, ratioSpecificHeats float64) ThermalData {
+	rise := (ratioSpecificHeats - 1) / 2 * mach * mach
+
+	return ThermalData{
+		Mach:                  mach,
+		StaticTemperature:     staticTemperature,
+		StagnationTemperature: staticTemperature * (1 + rise),
+		RecoveryTemperature:   staticTemperature * (1 + turbulentRecoveryFactor*rise),
+	}
+}
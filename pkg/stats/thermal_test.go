@@ -0,0 +1,43 @@
+package stats_test
+
+import (
+	"testing"
+
+	"github.com/bxrne/launchrail/pkg/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+// TEST: GIVEN a static temperature and Mach WHEN EstimateHeating is called THEN stagnation and recovery temperatures are returned
+func TestEstimateHeating(t *testing.T) {
+	heating := stats.EstimateHeating(288.15, 2.0, 1.4)
+	assert.Equal(t, 2.0, heating.Mach)
+	assert.InDelta(t, 288.15*1.8, heating.StagnationTemperature, 1e-9)
+	assert.InDelta(t, 288.15*(1+0.89*0.8), heating.RecoveryTemperature, 1e-9)
+	assert.Less(t, heating.RecoveryTemperature, heating.StagnationTemperature)
+
+	still := stats.EstimateHeating(288.15, 0, 1.4)
+	assert.Equal(t, 288.15, still.StagnationTemperature)
+}
+
+// TEST: GIVEN a FlightStats WHEN UpdateHeating is called THEN the estimates at max-Q and max speed are kept
+func TestFlightStatsUpdateHeating(t *testing.T) {
+	fs := stats.NewFlightStats(stats.ApogeeMax)
+
+	samples := []struct {
+		time, altitude, velocity, q float64
+	}{
+		{1.0, 100, 150, 13000},
+		{2.0, 400, 200, 20000},
+		{3.0, 2000, 220, 19000}, // Faster but thinner air
+		{4.0, 2500, 180, 12000},
+	}
+	for _, sample := range samples {
+		fs.Update(sample.time, sample.altitude, sample.velocity, 0, sample.velocity/340)
+		fs.UpdateHeating(sample.time, sample.q, stats.EstimateHeating(288, sample.velocity/340, 1.4))
+	}
+
+	assert.Equal(t, 20000.0, fs.MaxQ)
+	assert.Equal(t, 2.0, fs.TimeToMaxQ)
+	assert.InDelta(t, 200.0/340, fs.MaxQHeating.Mach, 1e-9)
+	assert.InDelta(t, 220.0/340, fs.MaxSpeedHeating.Mach, 1e-9)
+}
@@ -2,10 +2,10 @@ package systems
 
 import (
 	"math"
-	"sync"
 
 	"github.com/EngoEngine/ecs"
 	"github.com/bxrne/launchrail/internal/config"
+	"github.com/bxrne/launchrail/pkg/aerodeck"
 	"github.com/bxrne/launchrail/pkg/atmosphere"
 	"github.com/bxrne/launchrail/pkg/components"
 	"github.com/bxrne/launchrail/pkg/types"
@@ -19,23 +19,56 @@ type atmosphericData struct {
 	soundSpeed  float64
 }
 
+// DragBreakdown splits the drag on an entity between the body and the protrusions
+type DragBreakdown struct {
+	Body           float64 // N, from the body Cd over the reference area
+	Protrusions    float64 // N, from the protrusions' summed drag area
+	BodyCdA        float64 // m²
+	ProtrusionsCdA float64 // m²
+}
+
+// ProtrusionShare returns the fraction of the drag that comes from protrusions
+func (d DragBreakdown) ProtrusionShare() float64 {
+	total := d.Body + d.Protrusions
+	if total == 0 {
+		return 0
+	}
+	return d.Protrusions / total
+}
+
 // AerodynamicSystem calculates aerodynamic forces on entities
 type AerodynamicSystem struct {
-	world    *ecs.World
-	entities []PhysicsEntity
-	workers  int
-	isa      *atmosphere.ISAModel
+	world             *ecs.World
+	entities          []PhysicsEntity
+	isa               *atmosphere.ISAModel
+	parasiticDragArea float64        // Summed Cd·A of protrusions in m²
+	deck              *aerodeck.Deck // External aero data, overrides the internal drag model when set
 }
 
-func NewAerodynamicSystem(world *ecs.World, workers int, cfg *config.Config) *AerodynamicSystem {
+func NewAerodynamicSystem(world *ecs.World, cfg *config.Config) *AerodynamicSystem {
+	var parasiticDragArea float64
+	for _, p := range cfg.Options.Protrusions {
+		parasiticDragArea += p.DragArea
+	}
+
 	return &AerodynamicSystem{
-		world:    world,
-		entities: make([]PhysicsEntity, 0),
-		workers:  workers,
-		isa:      atmosphere.NewISAModel(&cfg.Options.Launchsite.Atmosphere.ISAConfiguration),
+		world:             world,
+		entities:          make([]PhysicsEntity, 0),
+		isa:               atmosphere.NewModel(&cfg.Options.Launchsite.Atmosphere),
+		parasiticDragArea: parasiticDragArea,
 	}
 }
 
+// GetParasiticDragArea returns the summed drag area (Cd·A) of the configured protrusions
+func (a *AerodynamicSystem) GetParasiticDragArea() float64 {
+	return a.parasiticDragArea
+}
+
+// SetAeroDeck replaces the internal drag model with table lookups from an external deck
+func (a *AerodynamicSystem) SetAeroDeck(deck *aerodeck.Deck) {
+	a.deck = deck
+}
+
 // getAtmosphericData retrieves atmospheric data from cache or calculates it
 func (a *AerodynamicSystem) getAtmosphericData(altitude float64) *atmosphericData {
 	isaData := a.isa.GetAtmosphere(altitude)
@@ -47,34 +80,44 @@ func (a *AerodynamicSystem) getAtmosphericData(altitude float64) *atmosphericDat
 	}
 }
 
-// GetTemperature calculates the temperature at a given altitude
-func (a *AerodynamicSystem) getTemperature(altitude float32) float32 {
-	return float32(a.isa.GetTemperature(float64(altitude)))
-}
-
-// CalculateDrag now handles atmospheric effects and Mach number
-func (a *AerodynamicSystem) CalculateDrag(entity PhysicsEntity) types.Vector3 {
+// DragBreakdown returns the drag magnitudes on an entity from the body and from the protrusions
+func (a *AerodynamicSystem) DragBreakdown(entity PhysicsEntity) DragBreakdown {
 	// Get atmospheric data
 	atmData := a.getAtmosphericData(entity.Position.Y)
 
-	// Get vector from pool
-	dragForce := vectorPool.Get().(*types.Vector3)
-	defer vectorPool.Put(dragForce)
-
 	// Calculate mach number
-	velocity := math.Sqrt(entity.Velocity.X*entity.Velocity.X +
+	velocity := speed(entity)
+	machNumber := a.isa.GetMach(velocity, entity.Position.Y)
+
+	// Calculate drag coefficient using Barrowman method over the reference area
+	bodyCdA := a.calculateDragCoeff(machNumber, entity) * calculateReferenceArea(entity.Nosecone, entity.Bodytube)
+
+	dynamicPressure := 0.5 * atmData.density * velocity * velocity
+	return DragBreakdown{
+		Body:           dynamicPressure * bodyCdA,
+		Protrusions:    dynamicPressure * a.parasiticDragArea,
+		BodyCdA:        bodyCdA,
+		ProtrusionsCdA: a.parasiticDragArea,
+	}
+}
+
+// speed returns the magnitude of an entity's velocity
+func speed(entity PhysicsEntity) float64 {
+	return math.Sqrt(entity.Velocity.X*entity.Velocity.X +
 		entity.Velocity.Y*entity.Velocity.Y +
 		entity.Velocity.Z*entity.Velocity.Z)
-	machNumber := velocity / atmData.soundSpeed
-
-	// Calculate drag coefficient using Barrowman method
-	cd := a.calculateDragCoeff(machNumber, entity)
+}
 
-	// Calculate reference area
-	area := calculateReferenceArea(entity.Nosecone, entity.Bodytube)
+// CalculateDrag now handles atmospheric effects and Mach number
+func (a *AerodynamicSystem) CalculateDrag(entity PhysicsEntity) types.Vector3 {
+	// Get vector from pool
+	dragForce := vectorPool.Get().(*types.Vector3)
+	defer vectorPool.Put(dragForce)
 
-	// Calculate drag force
-	forceMagnitude := 0.5 * cd * atmData.density * area * velocity * velocity
+	// Protrusions add their drag area on top of the body
+	breakdown := a.DragBreakdown(entity)
+	forceMagnitude := breakdown.Body + breakdown.Protrusions
+	velocity := speed(entity)
 
 	// Apply force in opposite direction of velocity
 	dragForce.X = -entity.Velocity.X * forceMagnitude / velocity
@@ -92,43 +135,8 @@ func calculateReferenceArea(nosecone *components.Nosecone, bodytube *components.
 	return math.Max(noseArea, tubeArea)
 }
 
-// Update implements parallel force calculation and application
+// Update does nothing, drag is queried through CalculateDrag by the physics system that integrates it
 func (a *AerodynamicSystem) Update(dt float32) error {
-	workChan := make(chan PhysicsEntity, len(a.entities))
-	resultChan := make(chan types.Vector3, len(a.entities))
-
-	var wg sync.WaitGroup
-	for i := 0; i < a.workers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for entity := range workChan {
-				// Perform a more accurate Mach-based drag calculation
-				force := a.CalculateDrag(entity)
-				resultChan <- force
-			}
-		}()
-	}
-
-	for _, entity := range a.entities {
-		workChan <- entity
-	}
-	close(workChan)
-
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	i := 0
-	for force := range resultChan {
-		entity := a.entities[i]
-		acc := force.DivideScalar(entity.Mass.Value)
-		entity.Acceleration.X += float64(acc.X)
-		entity.Acceleration.Y += float64(acc.Y)
-		entity.Acceleration.Z += float64(acc.Z)
-		i++
-	}
 	return nil
 }
 
@@ -142,17 +150,21 @@ func (a *AerodynamicSystem) Priority() int {
 	return 2
 }
 
-// calculateSoundSpeed calculates the speed of sound at a given temperature
+// GetSpeedOfSound returns the local speed of sound from the atmosphere model
 func (a *AerodynamicSystem) GetSpeedOfSound(altitude float32) float32 {
-	temperature := a.getTemperature(altitude)
-	if temperature <= 0 {
+	soundSpeed := a.isa.GetSpeedOfSound(float64(altitude))
+	if soundSpeed <= 0 || math.IsNaN(soundSpeed) {
 		return 340.29 // Return sea level speed of sound as fallback
 	}
-	return float32(math.Sqrt(float64(1.4 * 287.05 * temperature)))
+	return float32(soundSpeed)
 }
 
 // calculateDragCoeff calculates the drag coefficient based on Mach number
 func (a *AerodynamicSystem) calculateDragCoeff(mach float64, entity PhysicsEntity) float64 {
+	if a.deck != nil {
+		return a.deck.Lookup(mach).Cd
+	}
+
 	// More accurate drag coefficient calculation
 	baseCd := 0.2 // Subsonic base drag
 
@@ -169,21 +181,3 @@ func (a *AerodynamicSystem) calculateDragCoeff(mach float64, entity PhysicsEntit
 
 	return baseCd
 }
-
-// getAtmosphericDensity implements the International Standard Atmosphere model
-func getAtmosphericDensity(altitude float64) float64 {
-	// Constants for ISA model
-	const (
-		rho0 = 1.225     // sea level density in kg/m^3
-		T0   = 288.15    // sea level temperature in K
-		L    = 0.0065    // temperature lapse rate in K/m
-		g    = 9.80665   // gravitational acceleration in m/s^2
-		R    = 287.05287 // specific gas constant for air in J/(kg·K)
-	)
-
-	if altitude < 11000 { // troposphere
-		return rho0 * math.Pow(1-(L*altitude)/T0, g/(R*L)-1)
-	}
-	// Add stratosphere calculations if needed
-	return rho0 * math.Exp(-g*altitude/(R*T0))
-}
@@ -1,30 +1,42 @@
 package systems_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/EngoEngine/ecs"
 	"github.com/bxrne/launchrail/internal/config"
+	"github.com/bxrne/launchrail/pkg/aerodeck"
 	"github.com/bxrne/launchrail/pkg/components"
 	"github.com/bxrne/launchrail/pkg/systems"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// testISA is a standard sea level atmosphere
+var testISA = config.ISAConfiguration{
+	SpecificGasConstant:  287.05,
+	GravitationalAccel:   9.81,
+	SeaLevelDensity:      1.225,
+	SeaLevelTemperature:  288.15,
+	SeaLevelPressure:     101325,
+	RatioSpecificHeats:   1.4,
+	TemperatureLapseRate: 0.0065,
+}
+
 // TEST: GIVEN a new AerodynamicSystem WHEN NewAerodynamicSystem is called THEN a new AerodynamicSystem is returned
 func TestNewAerodynamicSystem(t *testing.T) {
 	world := &ecs.World{}
-	workers := 1
 	cfg := &config.Config{}
-	aero := systems.NewAerodynamicSystem(world, workers, cfg)
+	aero := systems.NewAerodynamicSystem(world, cfg)
 	require.NotNil(t, aero)
 }
 
 // TEST: GIVEN an AerodynamicSystem WHEN CalculateDrag is called THEN the drag force is calculated
 func TestAerodynamicSystem_CalculateDrag(t *testing.T) {
 	world := &ecs.World{}
-	workers := 1
 	cfg := &config.Config{}
-	aero := systems.NewAerodynamicSystem(world, workers, cfg)
+	aero := systems.NewAerodynamicSystem(world, cfg)
 	require.NotNil(t, aero)
 
 	// Create a physics entity
@@ -47,9 +59,8 @@ func TestAerodynamicSystem_CalculateDrag(t *testing.T) {
 // TEST: GIVEN an AerodynamicSystem WHEN Update is called THEN the system state is updated
 func TestAerodynamicSystem_Update(t *testing.T) {
 	world := &ecs.World{}
-	workers := 1
 	cfg := &config.Config{}
-	aero := systems.NewAerodynamicSystem(world, workers, cfg)
+	aero := systems.NewAerodynamicSystem(world, cfg)
 	require.NotNil(t, aero)
 
 	err := aero.Update(0.1)
@@ -59,9 +70,8 @@ func TestAerodynamicSystem_Update(t *testing.T) {
 // TEST: GIVEN a new AerodynamicsSystem WHEN Add is called THEN the entity is added to the system
 func TestAerodynamicSystem_Add(t *testing.T) {
 	world := &ecs.World{}
-	workers := 1
 	cfg := &config.Config{}
-	aero := systems.NewAerodynamicSystem(world, workers, cfg)
+	aero := systems.NewAerodynamicSystem(world, cfg)
 	require.NotNil(t, aero)
 
 	// Create a physics entity
@@ -82,9 +92,8 @@ func TestAerodynamicSystem_Add(t *testing.T) {
 // TEST: GIVEN a new AerodynamicsSystem WHEN Priority is called THEN the system priority is returned
 func TestAerodynamicSystem_Priority(t *testing.T) {
 	world := &ecs.World{}
-	workers := 1
 	cfg := &config.Config{}
-	aero := systems.NewAerodynamicSystem(world, workers, cfg)
+	aero := systems.NewAerodynamicSystem(world, cfg)
 	require.NotNil(t, aero)
 
 	priority := aero.Priority()
@@ -94,11 +103,163 @@ func TestAerodynamicSystem_Priority(t *testing.T) {
 // TEST: GIVEN a new AerodynamicsSystem WHEN GetSpeedOfSound is called THEN the speed of sound is returned
 func TestAerodynamicSystem_GetSpeedOfSound(t *testing.T) {
 	world := &ecs.World{}
-	workers := 1
 	cfg := &config.Config{}
-	aero := systems.NewAerodynamicSystem(world, workers, cfg)
+	aero := systems.NewAerodynamicSystem(world, cfg)
 	require.NotNil(t, aero)
 
 	speed := aero.GetSpeedOfSound(20)
 	require.Equal(t, float32(340.29), speed)
 }
+
+// TEST: GIVEN configured protrusions WHEN CalculateDrag is called THEN their drag area adds to the body drag
+func TestAerodynamicSystem_CalculateDragWithProtrusions(t *testing.T) {
+	isa := testISA
+	clean := &config.Config{Options: config.Options{Launchsite: config.Launchsite{Atmosphere: config.Atmosphere{ISAConfiguration: isa}}}}
+	cluttered := &config.Config{Options: config.Options{
+		Launchsite: config.Launchsite{Atmosphere: config.Atmosphere{ISAConfiguration: isa}},
+		Protrusions: []config.Protrusion{
+			{Name: "rail buttons", DragArea: 0.0002},
+			{Name: "camera shroud", DragArea: 0.0008},
+		},
+	}}
+
+	entity := systems.PhysicsEntity{
+		Entity:       &ecs.BasicEntity{},
+		Position:     &components.Position{Y: 0},
+		Velocity:     &components.Velocity{Y: 100},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{Value: 1},
+		Bodytube:     &components.Bodytube{Radius: 0.05},
+		Nosecone:     &components.Nosecone{Radius: 0.05},
+	}
+
+	cleanAero := systems.NewAerodynamicSystem(&ecs.World{}, clean)
+	clutteredAero := systems.NewAerodynamicSystem(&ecs.World{}, cluttered)
+	assert.Zero(t, cleanAero.GetParasiticDragArea())
+	assert.InDelta(t, 0.001, clutteredAero.GetParasiticDragArea(), 1e-12)
+
+	extra := clutteredAero.CalculateDrag(entity).Y - cleanAero.CalculateDrag(entity).Y
+	assert.InDelta(t, -0.5*1.225*0.001*100*100, extra, 0.01)
+}
+
+// TEST: GIVEN configured protrusions WHEN DragBreakdown is called THEN it splits the drag between the body and the protrusions
+func TestAerodynamicSystem_DragBreakdown(t *testing.T) {
+	cfg := &config.Config{Options: config.Options{
+		Launchsite: config.Launchsite{Atmosphere: config.Atmosphere{ISAConfiguration: testISA}},
+		Protrusions: []config.Protrusion{
+			{Name: "rail buttons", DragArea: 0.0002},
+			{Name: "camera shroud", DragArea: 0.0008},
+		},
+	}}
+
+	entity := systems.PhysicsEntity{
+		Entity:       &ecs.BasicEntity{},
+		Position:     &components.Position{Y: 0},
+		Velocity:     &components.Velocity{Y: 100},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{Value: 1},
+		Bodytube:     &components.Bodytube{Radius: 0.05},
+		Nosecone:     &components.Nosecone{Radius: 0.05},
+	}
+
+	aero := systems.NewAerodynamicSystem(&ecs.World{}, cfg)
+	breakdown := aero.DragBreakdown(entity)
+
+	q := 0.5 * 1.225 * 100 * 100
+	assert.InDelta(t, q*0.001, breakdown.Protrusions, 0.01)
+	assert.InDelta(t, 0.001, breakdown.ProtrusionsCdA, 1e-12)
+	assert.InDelta(t, q*breakdown.BodyCdA, breakdown.Body, 0.01)
+	assert.Greater(t, breakdown.Body, breakdown.Protrusions)
+
+	share := breakdown.Protrusions / (breakdown.Body + breakdown.Protrusions)
+	assert.InDelta(t, share, breakdown.ProtrusionShare(), 1e-12)
+	assert.Greater(t, breakdown.ProtrusionShare(), 0.0)
+	assert.Less(t, breakdown.ProtrusionShare(), 1.0)
+
+	// The parts add up to the applied drag
+	assert.InDelta(t, -(breakdown.Body + breakdown.Protrusions), aero.CalculateDrag(entity).Y, 1e-9)
+}
+
+// TEST: GIVEN no drag WHEN ProtrusionShare is called THEN it is zero
+func TestDragBreakdown_ProtrusionShareAtRest(t *testing.T) {
+	assert.Zero(t, systems.DragBreakdown{}.ProtrusionShare())
+}
+
+// TEST: GIVEN an AerodynamicSystem with an aero deck WHEN CalculateDrag is called THEN Cd comes from the deck
+func TestAerodynamicSystem_CalculateDragWithAeroDeck(t *testing.T) {
+	isa := testISA
+	cfg := &config.Config{Options: config.Options{Launchsite: config.Launchsite{Atmosphere: config.Atmosphere{ISAConfiguration: isa}}}}
+
+	entity := systems.PhysicsEntity{
+		Entity:       &ecs.BasicEntity{},
+		Position:     &components.Position{Y: 0},
+		Velocity:     &components.Velocity{Y: 100},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{Value: 1},
+		Bodytube:     &components.Bodytube{Radius: 0.05},
+		Nosecone:     &components.Nosecone{Radius: 0.05},
+	}
+
+	internal := systems.NewAerodynamicSystem(&ecs.World{}, cfg)
+	external := systems.NewAerodynamicSystem(&ecs.World{}, cfg)
+	deck, err := aerodeck.Parse(strings.NewReader("0.0,0.4,0.9,10\n1.0,0.4,0.9,10\n"))
+	require.NoError(t, err)
+	external.SetAeroDeck(deck)
+
+	// The internal model uses a subsonic Cd of 0.2
+	assert.InDelta(t, 2*internal.CalculateDrag(entity).Y, external.CalculateDrag(entity).Y, 1e-6)
+}
+
+// coastApogee flies an entity upwards from 1m at 100m/s under physics with drag from aero and returns its apogee
+func coastApogee(t *testing.T, cfg *config.Config, deck *aerodeck.Deck) float64 {
+	physics := systems.NewPhysicsSystem(&ecs.World{}, cfg)
+	aero := systems.NewAerodynamicSystem(&ecs.World{}, cfg)
+	aero.SetAeroDeck(deck)
+	physics.SetAerodynamics(aero)
+
+	e := ecs.NewBasic()
+	entity := systems.PhysicsEntity{
+		Entity:       &e,
+		Position:     &components.Position{Y: 1},
+		Velocity:     &components.Velocity{Y: 100},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{Value: 1},
+		Motor:        &components.Motor{},
+		Bodytube:     &components.Bodytube{Radius: 0.05},
+		Nosecone:     &components.Nosecone{Radius: 0.05},
+	}
+	physics.Add(&entity)
+
+	for i := 0; i < 20000 && entity.Velocity.Y > 0; i++ {
+		require.NoError(t, physics.Update(0.001))
+	}
+	return entity.Position.Y
+}
+
+// TEST: GIVEN an aero deck with more drag than the internal model WHEN a rocket coasts THEN its apogee is lower
+func TestAerodynamicSystem_AeroDeckChangesApogee(t *testing.T) {
+	cfg := &config.Config{Options: config.Options{Launchsite: config.Launchsite{Atmosphere: config.Atmosphere{ISAConfiguration: testISA}}}}
+	deck, err := aerodeck.Parse(strings.NewReader("0.0,0.8,0.9,10\n1.0,0.8,0.9,10\n"))
+	require.NoError(t, err)
+
+	internal := coastApogee(t, cfg, nil)
+	external := coastApogee(t, cfg, deck)
+	assert.Greater(t, internal, 300.0)
+	assert.Less(t, external, internal-20)
+}
+
+// TEST: GIVEN configured protrusions WHEN a rocket coasts THEN the extra drag lowers its apogee
+func TestAerodynamicSystem_ProtrusionsLowerApogee(t *testing.T) {
+	clean := &config.Config{Options: config.Options{Launchsite: config.Launchsite{Atmosphere: config.Atmosphere{ISAConfiguration: testISA}}}}
+	cluttered := &config.Config{Options: config.Options{
+		Launchsite: config.Launchsite{Atmosphere: config.Atmosphere{ISAConfiguration: testISA}},
+		Protrusions: []config.Protrusion{
+			{Name: "rail buttons", DragArea: 0.0002},
+			{Name: "camera shroud", DragArea: 0.0008},
+		},
+	}}
+
+	cleanApogee := coastApogee(t, clean, nil)
+	clutteredApogee := coastApogee(t, cluttered, nil)
+	assert.Less(t, clutteredApogee, cleanApogee-5)
+}
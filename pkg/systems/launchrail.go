@@ -4,13 +4,15 @@ import (
 	"math"
 
 	"github.com/EngoEngine/ecs"
+	"github.com/bxrne/launchrail/pkg/types"
 )
 
 // LaunchRail represents a launch rail
+// NOTE: Frame is Y up, X north and Z east, so an orientation of 90 tilts the rail towards +Z.
 type LaunchRail struct {
 	Length      float64
-	Angle       float64 // Angle from vertical in degrees
-	Orientation float64 // Compass orientation in degrees
+	Angle       float64 // Angle from vertical in radians
+	Orientation float64 // Compass orientation (azimuth) in radians
 }
 
 // LaunchRailSystem constrains entities to a launch rail
@@ -24,8 +26,9 @@ type LaunchRailSystem struct {
 
 // Add adds a physics entity to the launch rail system
 func NewLaunchRailSystem(world *ecs.World, length, angle, orientation float64) *LaunchRailSystem {
-	// Convert angle to radians
+	// Convert angles to radians
 	angleRad := angle * math.Pi / 180.0
+	orientationRad := orientation * math.Pi / 180.0
 
 	return &LaunchRailSystem{
 		world:    world,
@@ -33,13 +36,23 @@ func NewLaunchRailSystem(world *ecs.World, length, angle, orientation float64) *
 		rail: LaunchRail{
 			Length:      length,
 			Angle:       angleRad,
-			Orientation: orientation,
+			Orientation: orientationRad,
 		},
 		onRail:    true,
 		railExitY: length * math.Cos(angleRad), // Calculate Y position at rail exit
 	}
 }
 
+// GetRailDirection returns the unit vector along the rail from its elevation and azimuth
+func (s *LaunchRailSystem) GetRailDirection() types.Vector3 {
+	horizontal := math.Sin(s.rail.Angle)
+	return types.Vector3{
+		X: horizontal * math.Cos(s.rail.Orientation),
+		Y: math.Cos(s.rail.Angle),
+		Z: horizontal * math.Sin(s.rail.Orientation),
+	}
+}
+
 // Add adds a physics entity to the launch rail system
 func (s *LaunchRailSystem) Add(pe *PhysicsEntity) {
 	s.entities = append(s.entities, PhysicsEntity{pe.Entity, pe.Position, pe.Velocity, pe.Acceleration, pe.Mass, pe.Motor, pe.Bodytube, pe.Nosecone, pe.Finset})
@@ -57,25 +70,26 @@ func (s *LaunchRailSystem) Update(dt float32) error {
 			// Get total acceleration magnitude including thrust
 			totalAccel := entity.Acceleration.Y
 			if entity.Motor != nil {
-				thrust := entity.Motor.GetThrust()
-				totalAccel += thrust / entity.Mass.Value
+				thrust := entity.Motor.GetAverageThrust()
+				totalAccel += thrust / entity.TotalMass()
 			}
 
-			// Apply acceleration along rail direction
-			angleRad := s.rail.Angle
-			entity.Acceleration.X = float64(totalAccel) * math.Sin(angleRad)
-			entity.Acceleration.Y = float64(totalAccel) * math.Cos(angleRad)
-			entity.Acceleration.Z = 0
+			// Apply acceleration along rail direction (elevation and azimuth)
+			direction := s.GetRailDirection()
+			entity.Acceleration.X = float64(totalAccel) * direction.X
+			entity.Acceleration.Y = float64(totalAccel) * direction.Y
+			entity.Acceleration.Z = float64(totalAccel) * direction.Z
 
 			// Update velocity along rail
 			entity.Velocity.X = entity.Acceleration.X * float64(dt)
 			entity.Velocity.Y = entity.Acceleration.Y * float64(dt)
-			entity.Velocity.Z = 0
+			entity.Velocity.Z = entity.Acceleration.Z * float64(dt)
 
 			// Update position along rail
 			distanceAlongRail := math.Sqrt(
 				entity.Position.X*entity.Position.X +
-					entity.Position.Y*entity.Position.Y)
+					entity.Position.Y*entity.Position.Y +
+					entity.Position.Z*entity.Position.Z)
 
 			// Check if we've reached end of rail
 			if distanceAlongRail >= s.rail.Length {
@@ -128,3 +128,29 @@ func TestLaunchRailSystem_Priority(t *testing.T) {
 	priority := rail.Priority()
 	require.Equal(t, 1, priority)
 }
+
+// TEST: GIVEN rail angle and orientation combinations WHEN GetRailDirection is called THEN the rail points along elevation and azimuth
+func TestLaunchRailSystem_GetRailDirection(t *testing.T) {
+	tests := []struct {
+		name        string
+		angle       float64
+		orientation float64
+		want        [3]float64
+	}{
+		{"Vertical", 0, 45, [3]float64{0, 1, 0}},
+		{"Tilted north", 10, 0, [3]float64{math.Sin(10 * math.Pi / 180), math.Cos(10 * math.Pi / 180), 0}},
+		{"Tilted east", 10, 90, [3]float64{0, math.Cos(10 * math.Pi / 180), math.Sin(10 * math.Pi / 180)}},
+		{"Tilted south west", 30, 225, [3]float64{-0.5 * math.Sqrt(0.5), math.Cos(30 * math.Pi / 180), -0.5 * math.Sqrt(0.5)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rail := systems.NewLaunchRailSystem(&ecs.World{}, 2.0, tt.angle, tt.orientation)
+			direction := rail.GetRailDirection()
+			require.InDelta(t, tt.want[0], direction.X, 1e-9)
+			require.InDelta(t, tt.want[1], direction.Y, 1e-9)
+			require.InDelta(t, tt.want[2], direction.Z, 1e-9)
+			require.InDelta(t, 1.0, direction.Magnitude(), 1e-9)
+		})
+	}
+}
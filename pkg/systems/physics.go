@@ -6,6 +6,7 @@ import (
 
 	"github.com/EngoEngine/ecs"
 	"github.com/bxrne/launchrail/internal/config"
+	"github.com/bxrne/launchrail/pkg/atmosphere"
 	"github.com/bxrne/launchrail/pkg/barrowman"
 	"github.com/bxrne/launchrail/pkg/types"
 )
@@ -25,9 +26,9 @@ type PhysicsSystem struct {
 	entities     []*PhysicsEntity // Changed to store pointers
 	cpCalculator *barrowman.CPCalculator
 	workers      int
-	workChan     chan PhysicsEntity
-	resultChan   chan types.Vector3
 	gravity      float64
+	isa          *atmosphere.ISAModel
+	aero         *AerodynamicSystem // Drag source when set, otherwise a built-in estimate
 }
 
 // calculateStabilityForces calculates stability forces for an entity
@@ -54,55 +55,38 @@ func (s *PhysicsSystem) Remove(basic ecs.BasicEntity) {
 
 // NewPhysicsSystem creates a new PhysicsSystem
 func NewPhysicsSystem(world *ecs.World, cfg *config.Config) *PhysicsSystem {
-	workers := 4
+	workers := cfg.Simulation.Workers
+	if workers == 0 {
+		workers = defaultWorkers
+	}
+
 	return &PhysicsSystem{
 		world:        world,
 		entities:     make([]*PhysicsEntity, 0),
 		workers:      workers,
-		workChan:     make(chan PhysicsEntity, workers),
-		resultChan:   make(chan types.Vector3, workers),
 		cpCalculator: barrowman.NewCPCalculator(), // Initialize calculator
 		gravity:      cfg.Options.Launchsite.Atmosphere.ISAConfiguration.GravitationalAccel,
+		isa:          atmosphere.NewModel(&cfg.Options.Launchsite.Atmosphere),
 	}
 }
 
-// Update applies forces to entities
-func (s *PhysicsSystem) Update(dt float32) error {
-	var wg sync.WaitGroup
-	workChan := make(chan *PhysicsEntity, len(s.entities)) // Changed to pointer channel
-	resultChan := make(chan types.Vector3, len(s.entities))
-
-	// Launch multiple workers for concurrent force calculations
-	for i := 0; i < s.workers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for entity := range workChan {
-				force := vectorPool.Get().(*types.Vector3)
-				*force = types.Vector3{} // reset force
-				// ...existing or refined stability/forces code...
-				s.calculateStabilityForces(force, 0.0, *entity) // Dereference for backwards compatibility
-				resultChan <- *force
-				vectorPool.Put(force)
-			}
-		}()
-	}
-
-	for _, entity := range s.entities {
-		workChan <- entity
-	}
-	close(workChan)
-
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
+// SetAerodynamics integrates drag from the aerodynamic system, including any aero deck and protrusions
+func (s *PhysicsSystem) SetAerodynamics(aero *AerodynamicSystem) {
+	s.aero = aero
+}
 
-	i := 0
-	for force := range resultChan {
-		s.applyForce(s.entities[i], force, dt)
-		i++
-	}
+// Update applies forces to entities, stepping them in parallel across the workers.
+// Drag, atmosphere lookups and integration all run in the worker, they only write the entity
+// being stepped and the atmosphere cache is locked.
+func (s *PhysicsSystem) Update(dt float32) error {
+	forEachEntity(len(s.entities), s.workers, func(i int) {
+		force := vectorPool.Get().(*types.Vector3)
+		defer vectorPool.Put(force)
+
+		*force = types.Vector3{} // reset force
+		s.calculateStabilityForces(force, 0.0, *s.entities[i])
+		s.applyForce(s.entities[i], *force, dt)
+	})
 	return nil
 }
 
@@ -119,9 +103,9 @@ func (s *PhysicsSystem) handleGroundCollision(entity *PhysicsEntity) bool {
 func (s *PhysicsSystem) calculateNetForce(entity *PhysicsEntity, force types.Vector3) float64 {
 	var netForce float64
 
-	// Add thrust if motor is active
-	if entity.Motor != nil && !entity.Motor.IsCoasting() {
-		thrust := entity.Motor.GetThrust()
+	// Add the thrust averaged over the motor's last step, so each step delivers the curve's impulse
+	if entity.Motor != nil {
+		thrust := entity.Motor.GetAverageThrust()
 		if !math.IsNaN(thrust) {
 			netForce += thrust
 		}
@@ -131,24 +115,10 @@ func (s *PhysicsSystem) calculateNetForce(entity *PhysicsEntity, force types.Vec
 	velocity := math.Sqrt(entity.Velocity.X*entity.Velocity.X + entity.Velocity.Y*entity.Velocity.Y)
 
 	if velocity > 0 {
-		rho := getAtmosphericDensity(entity.Position.Y)
-		if math.IsNaN(rho) {
-			rho = 1.225 // Use sea level density as fallback
-		}
-
-		area := calculateReferenceArea(entity.Nosecone, entity.Bodytube)
-		cd := 0.3 // Base drag coefficient
-		if velocity > 100 {
-			cd = 0.5 // Increased drag at higher velocities
-		}
-
-		dragForce := 0.5 * rho * cd * area * velocity * velocity
-
-		// Apply drag in opposite direction of velocity
-		if entity.Velocity.Y > 0 {
-			netForce -= dragForce
+		if s.aero != nil {
+			netForce += s.aero.CalculateDrag(*entity).Y
 		} else {
-			netForce += dragForce
+			netForce += s.estimateDrag(entity, velocity)
 		}
 
 		// Add external force
@@ -158,8 +128,30 @@ func (s *PhysicsSystem) calculateNetForce(entity *PhysicsEntity, force types.Vec
 	return netForce
 }
 
+// estimateDrag returns a vertical drag force from a coarse constant Cd, for use without an aerodynamic system
+func (s *PhysicsSystem) estimateDrag(entity *PhysicsEntity, velocity float64) float64 {
+	rho := s.isa.GetAtmosphere(entity.Position.Y).Density
+	if math.IsNaN(rho) || math.IsInf(rho, 0) || rho <= 0 {
+		rho = 1.225 // Use sea level density as fallback
+	}
+
+	area := calculateReferenceArea(entity.Nosecone, entity.Bodytube)
+	cd := 0.3 // Base drag coefficient
+	if velocity > 100 {
+		cd = 0.5 // Increased drag at higher velocities
+	}
+
+	dragForce := 0.5 * rho * cd * area * velocity * velocity
+
+	// Apply drag in opposite direction of velocity
+	if entity.Velocity.Y > 0 {
+		return -dragForce
+	}
+	return dragForce
+}
+
 func (s *PhysicsSystem) updateEntityState(entity *PhysicsEntity, netForce float64, dt float64) {
-	entity.Acceleration.Y += netForce / entity.Mass.Value
+	entity.Acceleration.Y += netForce / entity.TotalMass()
 
 	// Semi-implicit Euler integration
 	newVelocity := entity.Velocity.Y + entity.Acceleration.Y*dt
@@ -182,7 +174,7 @@ func (s *PhysicsSystem) applyForce(entity *PhysicsEntity, force types.Vector3, d
 
 	// Validate timestep and mass
 	dt64 := float64(dt)
-	if dt64 <= 0 || math.IsNaN(dt64) || dt64 > 0.1 || entity.Mass.Value <= 0 {
+	if dt64 <= 0 || math.IsNaN(dt64) || dt64 > 0.1 || entity.TotalMass() <= 0 {
 		return
 	}
 
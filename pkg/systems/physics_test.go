@@ -1,6 +1,7 @@
 package systems_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -8,8 +9,10 @@ import (
 	"github.com/bxrne/launchrail/internal/config"
 	"github.com/bxrne/launchrail/pkg/components"
 	"github.com/bxrne/launchrail/pkg/systems"
+	"github.com/bxrne/launchrail/pkg/thrustcurves"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/zerodha/logf"
 )
 
 // TEST: GIVEN a new PhysicsSystem WHEN NewPhysicsSystem is called THEN a new PhysicsSystem is returned
@@ -213,3 +216,180 @@ func TestPhysicsSystem_Concurrent(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Less(t, duration, 100*time.Millisecond, "Concurrent update took too long")
 }
+
+// newBenchPhysicsSystem returns a physics system with n similar entities and drag from the aerodynamic system
+func newBenchPhysicsSystem(n, workers int) (*systems.PhysicsSystem, []*systems.PhysicsEntity) {
+	cfg := &config.Config{}
+	cfg.Options.Launchsite.Atmosphere.ISAConfiguration = testISA
+	cfg.Simulation.Workers = workers
+	system := systems.NewPhysicsSystem(&ecs.World{}, cfg)
+	system.SetAerodynamics(systems.NewAerodynamicSystem(&ecs.World{}, cfg))
+
+	entities := make([]*systems.PhysicsEntity, n)
+	for i := range entities {
+		e := ecs.NewBasic()
+		entities[i] = &systems.PhysicsEntity{
+			Entity:       &e,
+			Position:     &components.Position{Y: 100 + float64(i)},
+			Velocity:     &components.Velocity{Y: 50},
+			Acceleration: &components.Acceleration{},
+			Mass:         &components.Mass{Value: 1.0 + float64(i)*0.1},
+			Motor:        &components.Motor{},
+			Bodytube:     &components.Bodytube{Radius: 0.05},
+			Nosecone:     &components.Nosecone{Radius: 0.05},
+		}
+		system.Add(entities[i])
+	}
+	return system, entities
+}
+
+// TEST: GIVEN several entities WHEN updated with one or many workers THEN each entity ends in the same state
+func TestPhysicsSystem_WorkersDeterministic(t *testing.T) {
+	serial, serialEntities := newBenchPhysicsSystem(16, 1)
+	parallel, parallelEntities := newBenchPhysicsSystem(16, 8)
+
+	for step := 0; step < 50; step++ {
+		require.NoError(t, serial.Update(0.01))
+		require.NoError(t, parallel.Update(0.01))
+	}
+
+	for i := range serialEntities {
+		assert.Equal(t, serialEntities[i].Position.Y, parallelEntities[i].Position.Y, "entity %d", i)
+		assert.Equal(t, serialEntities[i].Velocity.Y, parallelEntities[i].Velocity.Y, "entity %d", i)
+	}
+}
+
+func BenchmarkPhysicsSystem_Update(b *testing.B) {
+	for _, n := range []int{1, 64} {
+		for _, workers := range []int{1, 4} {
+			b.Run(fmt.Sprintf("entities=%d/workers=%d", n, workers), func(b *testing.B) {
+				system, _ := newBenchPhysicsSystem(n, workers)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_ = system.Update(0.001)
+				}
+			})
+		}
+	}
+}
+
+// TEST: GIVEN a hotter configured atmosphere WHEN a coasting entity is updated THEN it decelerates less from drag
+func TestPhysicsSystem_UsesConfiguredAtmosphere(t *testing.T) {
+	coast := func(seaLevelTemperature float64) float64 {
+		cfg := &config.Config{}
+		cfg.Options.Launchsite.Atmosphere.ISAConfiguration = config.ISAConfiguration{
+			SpecificGasConstant:  287.05,
+			GravitationalAccel:   9.81,
+			SeaLevelDensity:      1.225,
+			SeaLevelTemperature:  seaLevelTemperature,
+			SeaLevelPressure:     101325,
+			RatioSpecificHeats:   1.4,
+			TemperatureLapseRate: 0.0065,
+		}
+		system := systems.NewPhysicsSystem(&ecs.World{}, cfg)
+
+		e := ecs.NewBasic()
+		motor := &components.Motor{}
+		motor.SetState("COASTING")
+		entity := systems.PhysicsEntity{
+			Entity:       &e,
+			Position:     &components.Position{Y: 100},
+			Velocity:     &components.Velocity{Y: 50},
+			Acceleration: &components.Acceleration{},
+			Mass:         &components.Mass{Value: 1},
+			Motor:        motor,
+			Bodytube:     &components.Bodytube{Radius: 0.05, Length: 1.0},
+			Nosecone:     &components.Nosecone{Radius: 0.05, Length: 0.3},
+			Finset:       &components.TrapezoidFinset{},
+		}
+		system.Add(&entity)
+		require.NoError(t, system.Update(0.05))
+		return entity.Velocity.Y
+	}
+
+	assert.Greater(t, coast(318.15), coast(288.15))
+}
+
+// TEST: GIVEN timesteps that don't align with the thrust curve WHEN a burning entity is updated THEN the impulse it receives matches the curve
+func TestPhysicsSystem_ImpulseIndependentOfTimestep(t *testing.T) {
+	md := &thrustcurves.MotorData{
+		Thrust:    [][]float64{{0.0, 5.0}, {0.03, 40.0}, {0.31, 32.0}, {0.77, 25.0}, {1.3, 0.0}},
+		TotalMass: 0.2,
+		WetMass:   0.1,
+		BurnTime:  1.3,
+	}
+	// 0.03*22.5 + 0.28*36 + 0.46*28.5 + 0.53*12.5
+	want := 0.675 + 10.08 + 13.11 + 6.625
+
+	for _, dt := range []float32{0.001, 0.0037, 0.01, 0.013, 0.07} {
+		// No gravity and no reference area, so thrust is the only force
+		system := systems.NewPhysicsSystem(&ecs.World{}, &config.Config{})
+
+		e := ecs.NewBasic()
+		motor := components.NewMotor(ecs.NewBasic(), md, logf.New(logf.Opts{}))
+		entity := systems.PhysicsEntity{
+			Entity:       &e,
+			Position:     &components.Position{Y: 1},
+			Velocity:     &components.Velocity{},
+			Acceleration: &components.Acceleration{},
+			Mass:         &components.Mass{Value: 2},
+			Motor:        motor,
+			Bodytube:     &components.Bodytube{},
+			Nosecone:     &components.Nosecone{},
+			Finset:       &components.TrapezoidFinset{},
+		}
+		system.Add(&entity)
+
+		// The motor's mass falls during the burn, so sum m·dv step by step
+		var impulse float64
+		for !motor.IsCoasting() {
+			require.NoError(t, motor.Update(float64(dt)))
+			mass, velocity := entity.TotalMass(), entity.Velocity.Y
+			require.NoError(t, system.Update(dt))
+			impulse += mass * (entity.Velocity.Y - velocity)
+		}
+		assert.InDelta(t, want, impulse, 1e-4, "dt=%v", dt)
+	}
+}
+
+// TEST: GIVEN a constant thrust motor WHEN the entity is updated through the burn THEN acceleration rises as propellant is consumed
+func TestPhysicsSystem_AccelerationRisesAsPropellantBurns(t *testing.T) {
+	md := &thrustcurves.MotorData{
+		Thrust:    [][]float64{{0.0, 10.0}, {1.0, 10.0}},
+		TotalMass: 1.0,
+		WetMass:   0.5,
+		BurnTime:  1.0,
+	}
+
+	// No gravity and no reference area, so thrust is the only force
+	system := systems.NewPhysicsSystem(&ecs.World{}, &config.Config{})
+
+	e := ecs.NewBasic()
+	motor := components.NewMotor(ecs.NewBasic(), md, logf.New(logf.Opts{}))
+	entity := systems.PhysicsEntity{
+		Entity:       &e,
+		Position:     &components.Position{Y: 1},
+		Velocity:     &components.Velocity{},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{Value: 1},
+		Motor:        motor,
+		Bodytube:     &components.Bodytube{},
+		Nosecone:     &components.Nosecone{},
+		Finset:       &components.TrapezoidFinset{},
+	}
+	system.Add(&entity)
+
+	var accelerations []float64
+	for i := 0; i < 90; i++ {
+		require.NoError(t, motor.Update(0.01))
+		require.NoError(t, system.Update(0.01))
+		accelerations = append(accelerations, entity.Acceleration.Y)
+	}
+
+	for i := 1; i < len(accelerations); i++ {
+		assert.Greater(t, accelerations[i], accelerations[i-1], "step %d", i)
+	}
+	// 10 N on the 2 kg wet rocket, rising towards 10 N on 1.5 kg near burnout
+	assert.InDelta(t, 10.0/(2.0-0.005), accelerations[0], 1e-9)
+	assert.InDelta(t, 10.0/(2.0-0.5*0.9), accelerations[89], 1e-9)
+}
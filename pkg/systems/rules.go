@@ -1,7 +1,11 @@
 package systems
 
 import (
+	"slices"
+	"sort"
+
 	"github.com/EngoEngine/ecs"
+	"github.com/bxrne/launchrail/internal/config"
 )
 
 // Event represents a significant event in flight
@@ -11,24 +15,100 @@ const (
 	None Event = iota - 1
 	Apogee
 	Land
+	MaxVelocity
+	MaxAcceleration
 )
 
+// String returns the display name of the event
+func (e Event) String() string {
+	switch e {
+	case Apogee:
+		return "Apogee"
+	case Land:
+		return "Land"
+	case MaxVelocity:
+		return "Max Velocity"
+	case MaxAcceleration:
+		return "Max Acceleration"
+	default:
+		return "None"
+	}
+}
+
+// TimedEvent is an event with the flight time it occurred at
+type TimedEvent struct {
+	Event Event
+	Time  float64
+}
+
 // RulesSystem enforces rules of flight
 type RulesSystem struct {
-	world     *ecs.World
-	entities  []PhysicsEntity
-	hadApogee bool    // Track if apogee has been reached
-	maxAlt    float64 // Track max altitude for apogee detection
+	world         *ecs.World
+	entities      []PhysicsEntity
+	hadLiftoff    bool    // Track if the rocket has left the pad, arms apogee and landing
+	hadApogee     bool    // Track if apogee has been reached
+	hadLanding    bool    // Track if landing has been reached
+	touchedDown   bool    // Ground contact after apogee, landing may still be held by hysteresis
+	maxAlt        float64 // Track max altitude for apogee detection
+	minTime       float64 // Minimum time between events
+	minAltitude   float64 // Minimum drop below max altitude to confirm apogee
+	elapsed       float64
+	lastEventTime float64
+	events        []Event
+	eventTimes    []float64 // Time of each event, parallel to events
 }
 
 // NewRulesSystem creates a new RulesSystem
-func NewRulesSystem(world *ecs.World) *RulesSystem {
+func NewRulesSystem(world *ecs.World, cfg *config.Config) *RulesSystem {
 	return &RulesSystem{
-		world:     world,
-		entities:  make([]PhysicsEntity, 0),
-		hadApogee: false,
-		maxAlt:    0,
+		world:         world,
+		entities:      make([]PhysicsEntity, 0),
+		hadApogee:     false,
+		maxAlt:        0,
+		minTime:       cfg.Simulation.EventHysteresis.MinTime,
+		minAltitude:   float64(cfg.Simulation.EventHysteresis.MinAltitude),
+		lastEventTime: -cfg.Simulation.EventHysteresis.MinTime,
+		events:        make([]Event, 0),
+	}
+}
+
+// GetEvents returns the events detected so far, in order
+func (s *RulesSystem) GetEvents() []Event {
+	return s.events
+}
+
+// GetTimeline returns the events detected so far with their times, in order
+func (s *RulesSystem) GetTimeline() []TimedEvent {
+	timeline := make([]TimedEvent, len(s.events))
+	for i, event := range s.events {
+		timeline[i] = TimedEvent{Event: event, Time: s.eventTimes[i]}
+	}
+	return timeline
+}
+
+// FindEvent returns the first occurrence of an event
+func (s *RulesSystem) FindEvent(event Event) (TimedEvent, bool) {
+	if i := slices.Index(s.events, event); i >= 0 {
+		return TimedEvent{Event: event, Time: s.eventTimes[i]}, true
 	}
+	return TimedEvent{}, false
+}
+
+// InsertEvent adds an event found after the fact, such as a global maximum, keeping the events in time order
+func (s *RulesSystem) InsertEvent(event Event, time float64) {
+	i := sort.Search(len(s.eventTimes), func(i int) bool { return s.eventTimes[i] > time })
+	s.events = slices.Insert(s.events, i, event)
+	s.eventTimes = slices.Insert(s.eventTimes, i, time)
+}
+
+// HadLiftoff reports whether the rocket has left the pad
+func (s *RulesSystem) HadLiftoff() bool {
+	return s.hadLiftoff
+}
+
+// HadApogee reports whether apogee has been detected
+func (s *RulesSystem) HadApogee() bool {
+	return s.hadApogee
 }
 
 // Add adds a physics entity to the rules system
@@ -38,7 +118,15 @@ func (s *RulesSystem) Add(pe *PhysicsEntity) {
 
 // Update applies rules of flight to entities
 func (s *RulesSystem) Update(dt float32) error {
+	s.elapsed += float64(dt)
+
 	event := s.processRules(dt)
+	if event != None {
+		s.events = append(s.events, event)
+		s.eventTimes = append(s.eventTimes, s.elapsed)
+		s.lastEventTime = s.elapsed
+	}
+
 	// Process the event if needed
 	switch event {
 	case Apogee:
@@ -53,6 +141,14 @@ func (s *RulesSystem) Update(dt float32) error {
 func (s *RulesSystem) processRules(dt float32) Event {
 	// Move existing Update logic here
 	for _, entity := range s.entities {
+		// Pad contact during an ignition delay or hold-down is not a flight
+		if entity.Position.Y > 0 {
+			s.hadLiftoff = true
+		}
+		if !s.hadLiftoff {
+			continue
+		}
+
 		if event := s.checkApogee(entity); event != None {
 			return event
 		}
@@ -71,7 +167,7 @@ func (s *RulesSystem) checkApogee(entity PhysicsEntity) Event {
 		s.maxAlt = currentAlt
 	}
 
-	if !s.hadApogee && currentVel < 0 {
+	if !s.hadApogee && currentVel < 0 && s.maxAlt-currentAlt >= s.minAltitude && s.separated() {
 		motorState := entity.Motor.GetState()
 		if motorState == "BURNOUT" || motorState == "COASTING" {
 			s.hadApogee = true
@@ -94,11 +190,23 @@ func (s *RulesSystem) checkLanding(entity PhysicsEntity) Event {
 		entity.Velocity.Y = 0
 		entity.Acceleration.Y = 0
 		entity.Motor.SetState("LANDED")
+		s.touchedDown = true
+	}
+
+	// Contact inside the hysteresis window is held until it passes, by then the velocity has
+	// been zeroed so only being on the ground confirms it. A bounce is not a second landing.
+	if s.touchedDown && !s.hadLanding && entity.Position.Y <= 0 && s.separated() {
+		s.hadLanding = true
 		return Land
 	}
 	return None
 }
 
+// separated reports whether enough time has passed since the last event
+func (s *RulesSystem) separated() bool {
+	return s.elapsed-s.lastEventTime >= s.minTime
+}
+
 // Remove removes an entity from the rules system
 func (s *RulesSystem) Remove(basic ecs.BasicEntity) {
 	var deleteIndex int = -1
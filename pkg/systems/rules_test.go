@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/EngoEngine/ecs"
+	"github.com/bxrne/launchrail/internal/config"
 	"github.com/bxrne/launchrail/pkg/components"
 	"github.com/bxrne/launchrail/pkg/systems"
 	"github.com/stretchr/testify/assert"
@@ -13,14 +14,14 @@ import (
 // TEST: GIVEN a new RulesSystem WHEN NewRulesSystem is called THEN a new RulesSystem is returned
 func TestNewRulesSystem(t *testing.T) {
 	world := &ecs.World{}
-	system := systems.NewRulesSystem(world)
+	system := systems.NewRulesSystem(world, &config.Config{})
 	require.NotNil(t, system)
 }
 
 // TEST: GIVEN a RulesSystem WHEN Add is called THEN the entity is added to the system
 func TestRulesSystem_Add(t *testing.T) {
 	world := &ecs.World{}
-	system := systems.NewRulesSystem(world)
+	system := systems.NewRulesSystem(world, &config.Config{})
 	e := ecs.NewBasic()
 
 	entity := systems.PhysicsEntity{
@@ -38,7 +39,7 @@ func TestRulesSystem_Add(t *testing.T) {
 // TEST: GIVEN a RulesSystem WHEN Priority is called THEN the correct priority is returned
 func TestRulesSystem_Priority(t *testing.T) {
 	world := &ecs.World{}
-	system := systems.NewRulesSystem(world)
+	system := systems.NewRulesSystem(world, &config.Config{})
 	assert.Equal(t, 100, system.Priority())
 }
 
@@ -89,7 +90,7 @@ func TestRulesSystem_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			world := &ecs.World{}
-			system := systems.NewRulesSystem(world)
+			system := systems.NewRulesSystem(world, &config.Config{})
 			e := ecs.NewBasic()
 
 			// Create position, velocity and motor with initial states
@@ -150,7 +151,7 @@ func TestRulesSystem_Update(t *testing.T) {
 // TEST: GIVEN a RulesSystem WHEN Remove is called THEN the entity is removed from the system
 func TestRulesSystem_Remove(t *testing.T) {
 	world := &ecs.World{}
-	system := systems.NewRulesSystem(world)
+	system := systems.NewRulesSystem(world, &config.Config{})
 	e := ecs.NewBasic()
 
 	entity := systems.PhysicsEntity{
@@ -165,3 +166,211 @@ func TestRulesSystem_Remove(t *testing.T) {
 	system.Add(&entity)
 	system.Remove(e)
 }
+
+// TEST: GIVEN flight events WHEN String is called THEN the display name is returned
+func TestEvent_String(t *testing.T) {
+	assert.Equal(t, "Apogee", systems.Apogee.String())
+	assert.Equal(t, "Land", systems.Land.String())
+	assert.Equal(t, "Max Velocity", systems.MaxVelocity.String())
+	assert.Equal(t, "Max Acceleration", systems.MaxAcceleration.String())
+	assert.Equal(t, "None", systems.None.String())
+}
+
+// TEST: GIVEN a bouncy trajectory WHEN Update is called with hysteresis THEN each event fires once
+func TestRulesSystem_Hysteresis(t *testing.T) {
+	// Velocity flickers around apogee and the rocket bounces on landing
+	trajectory := []struct {
+		altitude, velocity float64
+	}{
+		{100, 5}, {100.2, -0.1}, {100.1, 0.1}, {100.3, -0.2}, {99.5, -2},
+		{98, -4}, {50, -10}, {0, -5}, {0.2, 1}, {0, -1}, {0.1, 0.5}, {0, -0.5},
+	}
+
+	tests := []struct {
+		name       string
+		hysteresis config.EventHysteresis
+		want       []systems.Event
+		wantApogee int // Trajectory index at which apogee fires
+	}{
+		{"No thresholds", config.EventHysteresis{}, []systems.Event{systems.Apogee, systems.Land}, 1},
+		{"Altitude threshold", config.EventHysteresis{MinAltitude: 0.5}, []systems.Event{systems.Apogee, systems.Land}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Simulation.EventHysteresis = tt.hysteresis
+			system := systems.NewRulesSystem(&ecs.World{}, cfg)
+
+			e := ecs.NewBasic()
+			motor := &components.Motor{}
+			entity := systems.PhysicsEntity{
+				Entity:       &e,
+				Position:     &components.Position{},
+				Velocity:     &components.Velocity{},
+				Acceleration: &components.Acceleration{},
+				Mass:         &components.Mass{},
+				Motor:        motor,
+			}
+			system.Add(&entity)
+
+			apogeeAt := -1
+			for i, sample := range trajectory {
+				motor.SetState("COASTING")
+				entity.Position.Y = sample.altitude
+				entity.Velocity.Y = sample.velocity
+				require.NoError(t, system.Update(0.1))
+				if apogeeAt < 0 && len(system.GetEvents()) > 0 {
+					apogeeAt = i
+				}
+			}
+
+			assert.Equal(t, tt.want, system.GetEvents())
+			assert.Equal(t, tt.wantApogee, apogeeAt)
+		})
+	}
+}
+
+// TEST: GIVEN a minimum time between events WHEN landing follows apogee too closely THEN landing is deferred
+func TestRulesSystem_HysteresisMinTime(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Simulation.EventHysteresis.MinTime = 0.5
+	system := systems.NewRulesSystem(&ecs.World{}, cfg)
+
+	e := ecs.NewBasic()
+	motor := &components.Motor{}
+	entity := systems.PhysicsEntity{
+		Entity:       &e,
+		Position:     &components.Position{Y: 10},
+		Velocity:     &components.Velocity{Y: -1},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{},
+		Motor:        motor,
+	}
+	system.Add(&entity)
+
+	assert.False(t, system.HadApogee())
+	motor.SetState("COASTING")
+	require.NoError(t, system.Update(0.1))
+	assert.Equal(t, []systems.Event{systems.Apogee}, system.GetEvents())
+	assert.True(t, system.HadApogee())
+
+	// Touches down 0.1s after apogee, inside the window
+	entity.Position.Y, entity.Velocity.Y = 0, -1
+	require.NoError(t, system.Update(0.1))
+	assert.Equal(t, []systems.Event{systems.Apogee}, system.GetEvents())
+
+	for i := 0; i < 5; i++ {
+		entity.Position.Y, entity.Velocity.Y = 0, -1
+		require.NoError(t, system.Update(0.1))
+	}
+	assert.Equal(t, []systems.Event{systems.Apogee, systems.Land}, system.GetEvents())
+}
+
+// TEST: GIVEN a landing inside the hysteresis window WHEN the rocket stays on the ground THEN landing fires once the window passes
+func TestRulesSystem_HysteresisDefersLanding(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Simulation.EventHysteresis.MinTime = 0.5
+	system := systems.NewRulesSystem(&ecs.World{}, cfg)
+
+	e := ecs.NewBasic()
+	motor := &components.Motor{}
+	entity := systems.PhysicsEntity{
+		Entity:       &e,
+		Position:     &components.Position{Y: 10},
+		Velocity:     &components.Velocity{Y: -1},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{},
+		Motor:        motor,
+	}
+	system.Add(&entity)
+
+	motor.SetState("COASTING")
+	require.NoError(t, system.Update(0.1))
+	assert.Equal(t, []systems.Event{systems.Apogee}, system.GetEvents())
+
+	// Touches down 0.1s after apogee, inside the window, and the contact zeroes the velocity
+	entity.Position.Y, entity.Velocity.Y = 0, -1
+	require.NoError(t, system.Update(0.1))
+	assert.Equal(t, []systems.Event{systems.Apogee}, system.GetEvents())
+	assert.Zero(t, entity.Velocity.Y)
+
+	// Resting on the ground, as the physics system leaves it
+	for i := 0; i < 10; i++ {
+		require.NoError(t, system.Update(0.1))
+	}
+	assert.Equal(t, []systems.Event{systems.Apogee, systems.Land}, system.GetEvents())
+}
+
+// TEST: GIVEN a rocket sitting on the pad through a long ignition delay WHEN updated THEN no events fire until after liftoff
+func TestRulesSystem_IgnitionDelay(t *testing.T) {
+	system := systems.NewRulesSystem(&ecs.World{}, &config.Config{})
+
+	e := ecs.NewBasic()
+	motor := &components.Motor{}
+	entity := systems.PhysicsEntity{
+		Entity:       &e,
+		Position:     &components.Position{},
+		Velocity:     &components.Velocity{},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{},
+		Motor:        motor,
+	}
+	system.Add(&entity)
+
+	// Settling on the pad under gravity after a weak burn
+	motor.SetState("BURNOUT")
+	for i := 0; i < 50; i++ {
+		entity.Position.Y, entity.Velocity.Y = 0, -0.01
+		require.NoError(t, system.Update(0.1))
+	}
+	assert.False(t, system.HadLiftoff())
+	assert.Empty(t, system.GetEvents())
+
+	for _, sample := range []struct{ altitude, velocity float64 }{{10, 20}, {30, 0.5}, {29, -2}, {0, -5}} {
+		entity.Position.Y, entity.Velocity.Y = sample.altitude, sample.velocity
+		require.NoError(t, system.Update(0.1))
+	}
+	assert.True(t, system.HadLiftoff())
+	assert.Equal(t, []systems.Event{systems.Apogee, systems.Land}, system.GetEvents())
+}
+
+// TEST: GIVEN detected events WHEN events are inserted after the run THEN the timeline stays in time order and is searchable
+func TestRulesSystem_InsertEvent(t *testing.T) {
+	system := systems.NewRulesSystem(&ecs.World{}, &config.Config{})
+
+	e := ecs.NewBasic()
+	motor := &components.Motor{}
+	entity := systems.PhysicsEntity{
+		Entity:       &e,
+		Position:     &components.Position{},
+		Velocity:     &components.Velocity{},
+		Acceleration: &components.Acceleration{},
+		Mass:         &components.Mass{},
+		Motor:        motor,
+	}
+	system.Add(&entity)
+
+	motor.SetState("COASTING")
+	for _, sample := range []struct{ altitude, velocity float64 }{{10, 20}, {30, 0.5}, {29, -2}, {0, -5}} {
+		entity.Position.Y, entity.Velocity.Y = sample.altitude, sample.velocity
+		require.NoError(t, system.Update(0.5))
+	}
+
+	system.InsertEvent(systems.MaxAcceleration, 0.1)
+	system.InsertEvent(systems.MaxVelocity, 0.5)
+
+	assert.Equal(t, []systems.TimedEvent{
+		{Event: systems.MaxAcceleration, Time: 0.1},
+		{Event: systems.MaxVelocity, Time: 0.5},
+		{Event: systems.Apogee, Time: 1.5},
+		{Event: systems.Land, Time: 2.0},
+	}, system.GetTimeline())
+	assert.Equal(t, []systems.Event{systems.MaxAcceleration, systems.MaxVelocity, systems.Apogee, systems.Land}, system.GetEvents())
+
+	apogee, ok := system.FindEvent(systems.Apogee)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, apogee.Time)
+	_, ok = system.FindEvent(systems.None)
+	assert.False(t, ok)
+}
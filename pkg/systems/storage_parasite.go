@@ -2,6 +2,7 @@ package systems
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/EngoEngine/ecs"
 	"github.com/bxrne/launchrail/internal/storage"
@@ -9,20 +10,24 @@ import (
 
 // StorageParasiteSystem logs rocket state data to storage
 type StorageParasiteSystem struct {
-	world    *ecs.World
-	storage  *storage.Storage
-	entities []PhysicsEntity
-	dataChan chan RocketState
-	done     chan struct{}
+	world     *ecs.World
+	storage   storage.Store
+	entities  []PhysicsEntity
+	dataChan  chan RocketState
+	done      chan struct{}
+	precision int     // Significant figures, 0 for full precision
+	gravity   float64 // Local gravity for the acceleration in g column, 0 omits it
 }
 
 // NewStorageParasiteSystem creates a new StorageParasiteSystem
-func NewStorageParasiteSystem(world *ecs.World, storage *storage.Storage) *StorageParasiteSystem {
+func NewStorageParasiteSystem(world *ecs.World, storage storage.Store, precision int, gravity float64) *StorageParasiteSystem {
 	return &StorageParasiteSystem{
-		world:    world,
-		storage:  storage,
-		entities: make([]PhysicsEntity, 0),
-		done:     make(chan struct{}),
+		world:     world,
+		storage:   storage,
+		entities:  make([]PhysicsEntity, 0),
+		done:      make(chan struct{}),
+		precision: precision,
+		gravity:   gravity,
 	}
 }
 
@@ -43,11 +48,14 @@ func (s *StorageParasiteSystem) processData() {
 		select {
 		case state := <-s.dataChan:
 			record := []string{
-				fmt.Sprintf("%.6f", state.Time),
-				fmt.Sprintf("%.6f", state.Altitude),
-				fmt.Sprintf("%.6f", state.Velocity),
-				fmt.Sprintf("%.6f", state.Acceleration),
-				fmt.Sprintf("%.6f", state.Thrust),
+				s.format(state.Time),
+				s.format(state.Altitude),
+				s.format(state.Velocity),
+				s.format(state.Acceleration),
+				s.format(state.Thrust),
+			}
+			if s.gravity > 0 {
+				record = append(record, s.format(state.Acceleration/s.gravity))
 			}
 			if err := s.storage.Write(record); err != nil {
 				fmt.Printf("Error writing record: %v\n", err)
@@ -58,6 +66,14 @@ func (s *StorageParasiteSystem) processData() {
 	}
 }
 
+// format renders a value with the configured number of significant figures
+func (s *StorageParasiteSystem) format(value float64) string {
+	if s.precision <= 0 {
+		return fmt.Sprintf("%.6f", value)
+	}
+	return strconv.FormatFloat(value, 'g', s.precision, 64)
+}
+
 // Priority returns the system priority
 func (s *StorageParasiteSystem) Priority() int {
 	return 1
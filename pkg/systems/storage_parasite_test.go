@@ -1,8 +1,11 @@
 package systems_test
 
 import (
+	"encoding/csv"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"time"
 
@@ -42,7 +45,7 @@ func TestNewStorageParasiteSystem(t *testing.T) {
 	storage, cleanup := setupStorageTest(t)
 	defer cleanup()
 
-	system := systems.NewStorageParasiteSystem(world, storage)
+	system := systems.NewStorageParasiteSystem(world, storage, 0, 0)
 
 	assert.NotNil(t, system)
 }
@@ -53,7 +56,7 @@ func TestStorageParasiteSystem_ProcessData(t *testing.T) {
 	storage, cleanup := setupStorageTest(t)
 	defer cleanup()
 
-	system := systems.NewStorageParasiteSystem(world, storage)
+	system := systems.NewStorageParasiteSystem(world, storage, 0, 0)
 
 	dataChan := make(chan systems.RocketState)
 	system.Start(dataChan)
@@ -83,7 +86,7 @@ func TestStorageParasiteSystem_Add(t *testing.T) {
 	storage, cleanup := setupStorageTest(t)
 	defer cleanup()
 
-	system := systems.NewStorageParasiteSystem(world, storage)
+	system := systems.NewStorageParasiteSystem(world, storage, 0, 0)
 	e := ecs.NewBasic()
 
 	entity := systems.PhysicsEntity{
@@ -101,6 +104,77 @@ func TestStorageParasiteSystem_Priority(t *testing.T) {
 	storage, cleanup := setupStorageTest(t)
 	defer cleanup()
 
-	system := systems.NewStorageParasiteSystem(world, storage)
+	system := systems.NewStorageParasiteSystem(world, storage, 0, 0)
 	assert.Equal(t, 1, system.Priority())
 }
+
+// TEST: GIVEN a StorageParasiteSystem with reduced precision WHEN data is written THEN metrics from stored values stay within tolerance
+func TestStorageParasiteSystem_Precision(t *testing.T) {
+	world := &ecs.World{}
+	storage, cleanup := setupStorageTest(t)
+	defer cleanup()
+
+	system := systems.NewStorageParasiteSystem(world, storage, 6, 0)
+
+	dataChan := make(chan systems.RocketState)
+	system.Start(dataChan)
+
+	maxAltitude := 0.0
+	for i := 1; i <= 50; i++ {
+		altitude := 1234.56789 * math.Sin(float64(i)/50*math.Pi/2)
+		maxAltitude = math.Max(maxAltitude, altitude)
+		dataChan <- systems.RocketState{
+			Time:     float64(i) * 0.0123456789,
+			Altitude: altitude,
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	system.Stop()
+
+	file, err := os.Open(storage.GetFilePath())
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 51)
+
+	storedMax := 0.0
+	for _, record := range records[1:] {
+		altitude, err := strconv.ParseFloat(record[1], 64)
+		require.NoError(t, err)
+		storedMax = math.Max(storedMax, altitude)
+		assert.Equal(t, strconv.FormatFloat(altitude, 'g', 6, 64), record[1])
+	}
+
+	assert.InDelta(t, maxAltitude, storedMax, maxAltitude*1e-5)
+}
+
+// TEST: GIVEN a StorageParasiteSystem with gravity set WHEN data is written THEN acceleration in g is appended
+func TestStorageParasiteSystem_AccelerationG(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+	defer os.RemoveAll(filepath.Join(homeDir, "test_storage"))
+
+	store, err := storage.NewStorage("test_storage", "test_data")
+	require.NoError(t, err)
+	require.NoError(t, store.Init([]string{"Time", "Altitude", "Velocity", "Acceleration", "Thrust", "Acceleration_g"}))
+	defer store.Close()
+
+	system := systems.NewStorageParasiteSystem(&ecs.World{}, store, 0, 9.81)
+
+	dataChan := make(chan systems.RocketState)
+	system.Start(dataChan)
+	dataChan <- systems.RocketState{Time: 1.0, Acceleration: 98.1}
+	time.Sleep(100 * time.Millisecond)
+	system.Stop()
+
+	file, err := os.Open(store.GetFilePath())
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "10.000000", records[1][5])
+}
@@ -22,9 +22,18 @@ type PhysicsEntity struct {
 	Position     *components.Position
 	Velocity     *components.Velocity
 	Acceleration *components.Acceleration
-	Mass         *components.Mass
+	Mass         *components.Mass // Airframe mass, excluding the motor
 	Motor        *components.Motor
 	Bodytube     *components.Bodytube
 	Nosecone     *components.Nosecone
 	Finset       *components.TrapezoidFinset // Add this field
 }
+
+// TotalMass returns the airframe mass plus the motor's current mass, which falls as propellant burns
+func (pe *PhysicsEntity) TotalMass() float64 {
+	mass := pe.Mass.Value
+	if pe.Motor != nil {
+		mass += pe.Motor.GetMass()
+	}
+	return mass
+}
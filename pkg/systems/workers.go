@@ -0,0 +1,38 @@
+package systems
+
+import (
+	"sync"
+)
+
+// defaultWorkers is used when no worker count is configured
+const defaultWorkers = 4
+
+// forEachEntity runs step for each of n entities across workers. Steps must only touch their own entity
+// and shared state that is read-only or locked. A single entity or worker runs inline, as goroutines only
+// add overhead there.
+func forEachEntity(n, workers int, step func(i int)) {
+	if n <= 1 || workers <= 1 {
+		for i := 0; i < n; i++ {
+			step(i)
+		}
+		return
+	}
+
+	// Contiguous chunks, one per worker, keep the hand-off cost to a goroutine per worker rather than per entity
+	workers = min(workers, n)
+	chunk := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := min(start+chunk, n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each entity is stepped by exactly one worker, so results don't depend on scheduling
+			for i := start; i < end; i++ {
+				step(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
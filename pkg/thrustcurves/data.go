@@ -15,6 +15,9 @@ type MotorData struct {
 	TotalMass    float64     // Kg
 	WetMass      float64     // Kg
 	MaxThrust    float64     // Newtons
+	Diameter     float64     // Meters
+	Length       float64     // Meters
+	Delays       []float64   // Available ejection delays in seconds, ascending
 }
 
 // SearchResponse represents the response from the ThrustCurve search API
@@ -27,6 +30,9 @@ type SearchResponse struct {
 		BurnTime     float64 `json:"burnTimeS"`
 		TotalMass    float64 `json:"totalWeightG"`
 		WetMass      float64 `json:"propWeightG"`
+		Diameter     float64 `json:"diameter"` // Millimeters
+		Length       float64 `json:"length"`   // Millimeters
+		Delays       string  `json:"delays"`   // e.g. "6,10,14"
 	} `json:"results"`
 }
 
@@ -0,0 +1,49 @@
+package thrustcurves
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNoDelays is returned when a motor lists no ejection delays
+var ErrNoDelays = errors.New("motor lists no ejection delays")
+
+// ParseDelays reads a delay list such as "6,10,14" or "6-10-14", skipping plugged ("P") entries
+func ParseDelays(s string) []float64 {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '-' || r == ' '
+	})
+
+	delays := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		delay, err := strconv.ParseFloat(field, 64)
+		if err != nil || delay <= 0 {
+			continue
+		}
+		delays = append(delays, delay)
+	}
+	sort.Float64s(delays)
+
+	return delays
+}
+
+// RecommendDelay picks the available delay closest to the coast time from burnout to apogee,
+// reporting whether the coast time lies within the available range
+func (md *MotorData) RecommendDelay(coastTime float64) (float64, bool, error) {
+	if len(md.Delays) == 0 {
+		return 0, false, ErrNoDelays
+	}
+
+	best := md.Delays[0]
+	for _, delay := range md.Delays[1:] {
+		if math.Abs(delay-coastTime) < math.Abs(best-coastTime) {
+			best = delay
+		}
+	}
+
+	inRange := coastTime >= md.Delays[0] && coastTime <= md.Delays[len(md.Delays)-1]
+	return best, inRange, nil
+}
@@ -0,0 +1,36 @@
+package thrustcurves_test
+
+import (
+	"testing"
+
+	"github.com/bxrne/launchrail/pkg/thrustcurves"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TEST: GIVEN delay lists in ThrustCurve and RASP formats WHEN ParseDelays is called THEN sorted delays are returned without plugged entries
+func TestParseDelays(t *testing.T) {
+	assert.Equal(t, []float64{6, 10, 14}, thrustcurves.ParseDelays("14,6,10"))
+	assert.Equal(t, []float64{6, 10, 14}, thrustcurves.ParseDelays("6-10-14"))
+	assert.Equal(t, []float64{4}, thrustcurves.ParseDelays("4,P"))
+	assert.Empty(t, thrustcurves.ParseDelays("P"))
+	assert.Empty(t, thrustcurves.ParseDelays(""))
+}
+
+// TEST: GIVEN a motor with delays WHEN RecommendDelay is called THEN the closest delay and range check are returned
+func TestRecommendDelay(t *testing.T) {
+	md := &thrustcurves.MotorData{Delays: []float64{6, 10, 14}}
+
+	delay, inRange, err := md.RecommendDelay(9.1)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, delay)
+	assert.True(t, inRange)
+
+	delay, inRange, err = md.RecommendDelay(17)
+	require.NoError(t, err)
+	assert.Equal(t, 14.0, delay)
+	assert.False(t, inRange, "Apogee after the longest delay deploys early")
+
+	_, _, err = (&thrustcurves.MotorData{}).RecommendDelay(9)
+	assert.ErrorIs(t, err, thrustcurves.ErrNoDelays)
+}
@@ -0,0 +1,102 @@
+package thrustcurves
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bxrne/launchrail/pkg/designation"
+)
+
+// ParseRASP assembles motor data from a RASP (.eng) motor file
+func ParseRASP(data []byte, des designation.Designation) (*MotorData, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	var header []string
+	curve := [][]float64{}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if header == nil {
+			// name diameter(mm) length(mm) delays propellant(kg) total(kg) manufacturer
+			if len(fields) < 7 {
+				return nil, fmt.Errorf("invalid RASP header: %s", line)
+			}
+			header = fields
+			continue
+		}
+
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid RASP data point: %s", line)
+		}
+		t, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RASP time: %s", err)
+		}
+		thrust, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RASP thrust: %s", err)
+		}
+		curve = append(curve, []float64{t, thrust})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if header == nil || len(curve) == 0 {
+		return nil, fmt.Errorf("no curve data found in RASP file")
+	}
+
+	diameter, err := strconv.ParseFloat(header[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RASP diameter: %s", err)
+	}
+	length, err := strconv.ParseFloat(header[2], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RASP length: %s", err)
+	}
+	propellantMass, err := strconv.ParseFloat(header[4], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RASP propellant mass: %s", err)
+	}
+	totalMass, err := strconv.ParseFloat(header[5], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RASP total mass: %s", err)
+	}
+
+	// RASP curves start implicitly at zero thrust
+	var totalImpulse, maxThrust, prevTime, prevThrust float64
+	for _, point := range curve {
+		totalImpulse += (point[0] - prevTime) * (point[1] + prevThrust) / 2
+		maxThrust = math.Max(maxThrust, point[1])
+		prevTime, prevThrust = point[0], point[1]
+	}
+
+	burnTime := curve[len(curve)-1][0]
+	var avgThrust float64
+	if burnTime > 0 {
+		avgThrust = totalImpulse / burnTime
+	}
+
+	return &MotorData{
+		Designation:  des,
+		ID:           header[0],
+		Thrust:       curve,
+		TotalImpulse: totalImpulse,
+		BurnTime:     burnTime,
+		AvgThrust:    avgThrust,
+		TotalMass:    totalMass,
+		WetMass:      propellantMass,
+		MaxThrust:    maxThrust,
+		Diameter:     diameter / 1000, // Convert mm to m
+		Length:       length / 1000,   // Convert mm to m
+		Delays:       ParseDelays(header[3]),
+	}, nil
+}
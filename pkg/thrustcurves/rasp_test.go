@@ -0,0 +1,47 @@
+package thrustcurves_test
+
+import (
+	"testing"
+
+	"github.com/bxrne/launchrail/pkg/thrustcurves"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const raspMotor = `; Test motor
+H110 29 200 14 0.12 0.25 Test
+0.1 100.0
+1.0 100.0
+1.1 0.0
+;
+`
+
+// TEST: GIVEN a RASP motor file WHEN ParseRASP is called THEN the curve and derived data are returned
+func TestParseRASP(t *testing.T) {
+	md, err := thrustcurves.ParseRASP([]byte(raspMotor), "269H110-14A")
+	require.NoError(t, err)
+
+	assert.Equal(t, "H110", md.ID)
+	assert.Equal(t, [][]float64{{0.1, 100.0}, {1.0, 100.0}, {1.1, 0.0}}, md.Thrust)
+	assert.InDelta(t, 5.0+90.0+5.0, md.TotalImpulse, 1e-9)
+	assert.Equal(t, 1.1, md.BurnTime)
+	assert.Equal(t, 100.0, md.MaxThrust)
+	assert.Equal(t, 0.25, md.TotalMass)
+	assert.Equal(t, 0.12, md.WetMass)
+	assert.Equal(t, 0.029, md.Diameter)
+	assert.Equal(t, 0.2, md.Length)
+	assert.Equal(t, []float64{14}, md.Delays)
+}
+
+// TEST: GIVEN malformed RASP data WHEN ParseRASP is called THEN an error is returned
+func TestParseRASP_Invalid(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"H110 29 200\n0.1 100\n",
+		"H110 29 200 14 0.12 0.25 Test\n",
+		"H110 29 200 14 0.12 0.25 Test\n0.1 abc\n",
+	} {
+		_, err := thrustcurves.ParseRASP([]byte(data), "269H110-14A")
+		assert.Error(t, err, data)
+	}
+}
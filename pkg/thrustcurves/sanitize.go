@@ -0,0 +1,33 @@
+package thrustcurves
+
+// ClampNegativeThrust returns a copy of the curve with negative thrust set to zero and the number of points clamped
+func ClampNegativeThrust(curve [][]float64) ([][]float64, int) {
+	clamped := 0
+	sanitized := make([][]float64, len(curve))
+	for i, point := range curve {
+		sanitized[i] = append([]float64(nil), point...)
+		if len(point) > 1 && point[1] < 0 {
+			sanitized[i][1] = 0
+			clamped++
+		}
+	}
+	return sanitized, clamped
+}
+
+// SmoothTail returns a copy of the curve with a three point moving average applied after peak thrust
+func SmoothTail(curve [][]float64) [][]float64 {
+	smoothed := make([][]float64, len(curve))
+	peak := 0
+	for i, point := range curve {
+		smoothed[i] = append([]float64(nil), point...)
+		if point[1] > curve[peak][1] {
+			peak = i
+		}
+	}
+
+	// Endpoints are kept so burnout still reaches the final sample
+	for i := peak + 1; i < len(curve)-1; i++ {
+		smoothed[i][1] = (curve[i-1][1] + curve[i][1] + curve[i+1][1]) / 3
+	}
+	return smoothed
+}
@@ -0,0 +1,41 @@
+package thrustcurves_test
+
+import (
+	"testing"
+
+	"github.com/bxrne/launchrail/pkg/thrustcurves"
+	"github.com/stretchr/testify/assert"
+)
+
+// impulse integrates a curve with the trapezoidal rule
+func impulse(curve [][]float64) float64 {
+	var total float64
+	for i := 1; i < len(curve); i++ {
+		total += 0.5 * (curve[i][1] + curve[i-1][1]) * (curve[i][0] - curve[i-1][0])
+	}
+	return total
+}
+
+// TEST: GIVEN a curve with noisy negative thrust at cutoff WHEN ClampNegativeThrust is called THEN negatives are zeroed and counted
+func TestClampNegativeThrust(t *testing.T) {
+	curve := [][]float64{{0, 0}, {0.1, 50}, {1.0, 45}, {1.1, 2}, {1.12, -0.3}, {1.15, 0.1}, {1.2, -0.2}}
+
+	clamped, count := thrustcurves.ClampNegativeThrust(curve)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 0.0, clamped[4][1])
+	assert.Equal(t, 0.0, clamped[6][1])
+	assert.Equal(t, -0.3, curve[4][1], "The input curve is not modified")
+	assert.InDelta(t, impulse(curve), impulse(clamped), 0.01*impulse(curve))
+}
+
+// TEST: GIVEN a curve with a noisy tail WHEN SmoothTail is called THEN only points after peak thrust are averaged
+func TestSmoothTail(t *testing.T) {
+	curve := [][]float64{{0, 0}, {0.1, 30}, {0.2, 60}, {0.5, 40}, {0.6, 10}, {0.7, 30}, {0.9, 0}}
+
+	smoothed := thrustcurves.SmoothTail(curve)
+	assert.Equal(t, curve[:3], smoothed[:3])
+	assert.InDelta(t, (60.0+40+10)/3, smoothed[3][1], 1e-9)
+	assert.InDelta(t, (40.0+10+30)/3, smoothed[4][1], 1e-9)
+	assert.InDelta(t, (10.0+30+0)/3, smoothed[5][1], 1e-9)
+	assert.Equal(t, curve[6], smoothed[6])
+}
@@ -37,6 +37,9 @@ func Load(designationString string, client http_client.HTTPClient) (*MotorData,
 		TotalMass:    props.Results[0].TotalMass / 1000, // Convert grams to kg
 		WetMass:      props.Results[0].WetMass / 1000,   // Convert grams to kg
 		MaxThrust:    props.Results[0].MaxThrust,
+		Diameter:     props.Results[0].Diameter / 1000, // Convert mm to m
+		Length:       props.Results[0].Length / 1000,   // Convert mm to m
+		Delays:       ParseDelays(props.Results[0].Delays),
 	}, nil
 
 }